@@ -0,0 +1,136 @@
+package moonapi
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cstdev/moonapi/query"
+)
+
+// The pages and forms ascents are logged and deleted with. Like those used
+// by createProblem these have not been checked against a recording of the
+// website, testdata/README.md describes where the names come from.
+const (
+	logAscentUrl     = "Logbook/Add/"
+	logAscentForm    = "#frmLogAscent"
+	deleteAscentUrl  = "Logbook/Delete/"
+	deleteAscentForm = "#frmDeleteAscent"
+)
+
+// ascentDateFormat is the format the logbook form takes the date climbed in.
+const ascentDateFormat = "2006-01-02"
+
+// maxStars is the highest rating a problem can be given.
+const maxStars = 3
+
+// AscentOptions describes an ascent of a problem to log.
+// Date defaults to today, Tries is the number of attempts taken with 1
+// being a flash, Stars rates the problem from 1 to 3 or 0 to leave it
+// unrated. UserGrade is the grade the climber thought the problem was,
+// empty to agree with the setter's grade.
+type AscentOptions struct {
+	Date      time.Time
+	Tries     int
+	Stars     int
+	UserGrade string
+	Comment   string
+}
+
+// InvalidAscentError is returned by LogAscent when the options can't be
+// logged, holding a *Violation for every invalid option.
+type InvalidAscentError struct {
+	Errors []error
+}
+
+func (e *InvalidAscentError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return "invalid ascent: " + strings.Join(messages, "; ")
+}
+
+// validate returns a Violation for every option that can't be logged.
+func (o AscentOptions) validate() []error {
+	var errs []error
+	violation := func(field string, value string, reason string) {
+		errs = append(errs, &Violation{Field: field, Value: value, Reason: reason})
+	}
+
+	if o.Tries < 1 {
+		violation("Tries", strconv.Itoa(o.Tries), "tries must be at least 1")
+	}
+	if o.Stars < 0 || o.Stars > maxStars {
+		violation("Stars", strconv.Itoa(o.Stars), "stars must be 0 to "+strconv.Itoa(maxStars))
+	}
+	if o.UserGrade != "" {
+		var grade query.Grade
+		if err := grade.UnmarshalText([]byte(o.UserGrade)); err != nil {
+			violation("UserGrade", o.UserGrade, "invalid grade '"+o.UserGrade+"'")
+		}
+	}
+	if o.Date.After(time.Now()) {
+		violation("Date", o.Date.Format(ascentDateFormat), "ascents can't be logged in the future")
+	}
+	return errs
+}
+
+// LogAscent adds an ascent of the problem with the given Id to the
+// session's logbook, along with the rating and grade given to it.
+// It requires the session to provide the _MoonBoard AuthToken.
+// errors are returned as an *InvalidAscentError if the options are invalid,
+// if the session has expired, or a *RejectedError if the website rejects the
+// ascent
+func (m MoonBoard) LogAscent(problemID int, options AscentOptions) error {
+	if errs := options.validate(); len(errs) > 0 {
+		return &InvalidAscentError{Errors: errs}
+	}
+
+	date := options.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	path := logAscentUrl + strconv.Itoa(problemID)
+	bow, err := m.submitForm(path, logAscentForm, "log ascent", []formField{
+		{"DateClimbed", date.Format(ascentDateFormat)},
+		{"NumberOfTries", strconv.Itoa(options.Tries)},
+		{"Rating", strconv.Itoa(options.Stars)},
+		{"UserGrade", strings.ToUpper(options.UserGrade)},
+		{"Comment", options.Comment},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := rejection(bow); err != nil {
+		return err
+	}
+	if strings.Contains(bow.Url().Path, "/"+path) {
+		return errors.New("ascent was not logged, the website gave no reason")
+	}
+	return nil
+}
+
+// DeleteAscent removes the session's ascent of the problem with the given
+// Id from their logbook.
+// It requires the session to provide the _MoonBoard AuthToken.
+// errors are returned if the session has expired, or a *RejectedError if
+// the website refuses to delete the ascent
+func (m MoonBoard) DeleteAscent(problemID int) error {
+	path := deleteAscentUrl + strconv.Itoa(problemID)
+	bow, err := m.submitForm(path, deleteAscentForm, "delete ascent", nil)
+	if err != nil {
+		return err
+	}
+
+	if err := rejection(bow); err != nil {
+		return err
+	}
+	if strings.Contains(bow.Url().Path, "/"+path) {
+		return errors.New("ascent was not deleted, the website gave no reason")
+	}
+	return nil
+}
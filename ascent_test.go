@@ -0,0 +1,256 @@
+package moonapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"gopkg.in/jarcoal/httpmock.v1"
+)
+
+func TestLogAscentSubmitsOptions(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://moonboard.com/Logbook/Add/305445",
+		httpmock.NewStringResponder(200, fixture(t, "logAscent.html")))
+
+	var submitted http.Request
+	httpmock.RegisterResponder("POST", "https://moonboard.com/Logbook/Add/305445",
+		func(req *http.Request) (*http.Response, error) {
+			req.ParseForm()
+			submitted = *req
+			resp := httpmock.NewStringResponse(302, "")
+			resp.Header.Set("Location", "https://moonboard.com/Logbook/Index")
+			return resp, nil
+		},
+	)
+	httpmock.RegisterResponder("GET", "https://moonboard.com/Logbook/Index",
+		httpmock.NewStringResponder(200, "<html><body>Logbook</body></html>"))
+
+	err := createProblemSession().LogAscent(305445, AscentOptions{
+		Date:      time.Date(2020, 3, 14, 0, 0, 0, 0, time.UTC),
+		Tries:     3,
+		Stars:     2,
+		UserGrade: "6c+",
+		Comment:   "Tricky start",
+	})
+	if err != nil {
+		t.Errorf("Expected ascent to be logged, recieved error: %s", err.Error())
+		t.FailNow()
+	}
+
+	expected := map[string]string{
+		"__RequestVerificationToken": "FormToken",
+		"ProblemId":                  "305445",
+		"DateClimbed":                "2020-03-14",
+		"NumberOfTries":              "3",
+		"Rating":                     "2",
+		"UserGrade":                  "6C+",
+		"Comment":                    "Tricky start",
+	}
+	for field, value := range expected {
+		if submitted.PostForm.Get(field) != value {
+			t.Errorf("Expected %s to be '%s', got '%s'", field, value, submitted.PostForm.Get(field))
+		}
+	}
+}
+
+func TestLogAscentDefaultsDateToToday(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://moonboard.com/Logbook/Add/305445",
+		httpmock.NewStringResponder(200, fixture(t, "logAscent.html")))
+
+	var submitted http.Request
+	httpmock.RegisterResponder("POST", "https://moonboard.com/Logbook/Add/305445",
+		func(req *http.Request) (*http.Response, error) {
+			req.ParseForm()
+			submitted = *req
+			resp := httpmock.NewStringResponse(302, "")
+			resp.Header.Set("Location", "https://moonboard.com/Logbook/Index")
+			return resp, nil
+		},
+	)
+	httpmock.RegisterResponder("GET", "https://moonboard.com/Logbook/Index",
+		httpmock.NewStringResponder(200, "<html><body>Logbook</body></html>"))
+
+	err := createProblemSession().LogAscent(305445, AscentOptions{Tries: 1})
+	if err != nil {
+		t.Errorf("Expected ascent to be logged, recieved error: %s", err.Error())
+		t.FailNow()
+	}
+
+	today := time.Now().Format("2006-01-02")
+	if submitted.PostForm.Get("DateClimbed") != today {
+		t.Errorf("Expected DateClimbed to be today, %s, got '%s'", today, submitted.PostForm.Get("DateClimbed"))
+	}
+}
+
+func TestLogAscentAcceptsFivePlus(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://moonboard.com/Logbook/Add/305445",
+		httpmock.NewStringResponder(200, fixture(t, "logAscent.html")))
+
+	var submitted http.Request
+	httpmock.RegisterResponder("POST", "https://moonboard.com/Logbook/Add/305445",
+		func(req *http.Request) (*http.Response, error) {
+			req.ParseForm()
+			submitted = *req
+			resp := httpmock.NewStringResponse(302, "")
+			resp.Header.Set("Location", "https://moonboard.com/Logbook/Index")
+			return resp, nil
+		},
+	)
+	httpmock.RegisterResponder("GET", "https://moonboard.com/Logbook/Index",
+		httpmock.NewStringResponder(200, "<html><body>Logbook</body></html>"))
+
+	err := createProblemSession().LogAscent(305445, AscentOptions{Tries: 1, UserGrade: "5+"})
+	if err != nil {
+		t.Errorf("Expected a 5+ ascent to be logged, recieved error: %s", err.Error())
+		t.FailNow()
+	}
+	if submitted.PostForm.Get("UserGrade") != "5+" {
+		t.Errorf("Expected UserGrade to be '5+', got '%s'", submitted.PostForm.Get("UserGrade"))
+	}
+}
+
+func TestLogAscentInvalidOptionsReturnViolation(t *testing.T) {
+	tests := []struct {
+		options AscentOptions
+		field   string
+	}{
+		{AscentOptions{Tries: 0}, "Tries"},
+		{AscentOptions{Tries: 1, Stars: 4}, "Stars"},
+		{AscentOptions{Tries: 1, Stars: -1}, "Stars"},
+		{AscentOptions{Tries: 1, UserGrade: "9Z"}, "UserGrade"},
+		{AscentOptions{Tries: 1, Date: time.Now().Add(48 * time.Hour)}, "Date"},
+	}
+
+	for _, test := range tests {
+		err := createProblemSession().LogAscent(305445, test.options)
+		invalid, ok := err.(*InvalidAscentError)
+		if !ok || len(invalid.Errors) != 1 {
+			t.Errorf("Expected an *InvalidAscentError with one violation for %+v, got %v", test.options, err)
+			continue
+		}
+		violation, ok := invalid.Errors[0].(*Violation)
+		if !ok || violation.Field != test.field {
+			t.Errorf("Expected a violation of %s, got %v", test.field, invalid.Errors[0])
+		}
+	}
+}
+
+func TestLogAscentReturnsEveryViolation(t *testing.T) {
+	err := createProblemSession().LogAscent(305445, AscentOptions{
+		Tries:     0,
+		Stars:     5,
+		UserGrade: "6Z",
+		Date:      time.Now().Add(48 * time.Hour),
+	})
+	invalid, ok := err.(*InvalidAscentError)
+	if !ok {
+		t.Errorf("Expected an *InvalidAscentError, got %v", err)
+		t.FailNow()
+	}
+
+	expected := []string{"Tries", "Stars", "UserGrade", "Date"}
+	if len(invalid.Errors) != len(expected) {
+		t.Errorf("Expected %d violations, got %d: %s", len(expected), len(invalid.Errors), err.Error())
+		t.FailNow()
+	}
+	for i, field := range expected {
+		if violation := invalid.Errors[i].(*Violation); violation.Field != field {
+			t.Errorf("Expected violation %d to be of %s, got %s", i, field, violation.Field)
+		}
+	}
+}
+
+func TestLogAscentRejectedReturnsTypedErrors(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://moonboard.com/Logbook/Add/305445",
+		httpmock.NewStringResponder(200, fixture(t, "logAscent.html")))
+	httpmock.RegisterResponder("POST", "https://moonboard.com/Logbook/Add/305445",
+		httpmock.NewStringResponder(200, fixture(t, "logAscentRejected.html")))
+
+	err := createProblemSession().LogAscent(305445, AscentOptions{Tries: 1})
+	rejected, ok := err.(*RejectedError)
+	if !ok {
+		t.Errorf("Expected a *RejectedError, got %v", err)
+		t.FailNow()
+	}
+	if len(rejected.Errors) != 1 || rejected.Errors[0].Message != "You have already logged this problem today" {
+		t.Errorf("Expected the website's message, got: %s", err.Error())
+	}
+}
+
+func TestLogAscentWithoutReasonReturnsError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://moonboard.com/Logbook/Add/305445",
+		httpmock.NewStringResponder(200, fixture(t, "logAscent.html")))
+	httpmock.RegisterResponder("POST", "https://moonboard.com/Logbook/Add/305445",
+		httpmock.NewStringResponder(200, fixture(t, "logAscent.html")))
+
+	err := createProblemSession().LogAscent(305445, AscentOptions{Tries: 1})
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+	}
+}
+
+func TestDeleteAscent(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://moonboard.com/Logbook/Delete/305445",
+		httpmock.NewStringResponder(200, fixture(t, "deleteAscent.html")))
+
+	deleted := false
+	httpmock.RegisterResponder("POST", "https://moonboard.com/Logbook/Delete/305445",
+		func(req *http.Request) (*http.Response, error) {
+			req.ParseForm()
+			deleted = req.PostForm.Get("__RequestVerificationToken") == "FormToken" &&
+				req.PostForm.Get("ProblemId") == "305445"
+			resp := httpmock.NewStringResponse(302, "")
+			resp.Header.Set("Location", "https://moonboard.com/Logbook/Index")
+			return resp, nil
+		},
+	)
+	httpmock.RegisterResponder("GET", "https://moonboard.com/Logbook/Index",
+		httpmock.NewStringResponder(200, "<html><body>Logbook</body></html>"))
+
+	err := createProblemSession().DeleteAscent(305445)
+	if err != nil {
+		t.Errorf("Expected ascent to be deleted, recieved error: %s", err.Error())
+		t.FailNow()
+	}
+	if !deleted {
+		t.Errorf("Expected the delete form to be submitted with its token and problem Id")
+	}
+}
+
+func TestDeleteAscentOnExpiredSessionReturnsError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://moonboard.com/Logbook/Delete/305445",
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(302, "")
+			resp.Header.Set("Location", "https://moonboard.com/Account/Login")
+			return resp, nil
+		},
+	)
+	httpmock.RegisterResponder("GET", "https://moonboard.com/Account/Login",
+		httpmock.NewStringResponder(200, loginForm))
+
+	err := createProblemSession().DeleteAscent(305445)
+	if err == nil || err.Error() != "session expired, please log in" {
+		t.Errorf("Expected session expired error, got: %v", err)
+	}
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/render"
+)
+
+// printBoards draws each problem on the board in the terminal.
+func printBoards(problems []moonapi.Problem, colour bool, unicode bool) {
+	options := render.DefaultTextOptions
+	options.Colour = colour
+	if unicode {
+		options.Glyphs = render.UnicodeGlyphs
+	}
+
+	for _, problem := range problems {
+		check(render.Text(os.Stdout, problem, options))
+		fmt.Println()
+	}
+}
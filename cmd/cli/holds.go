@@ -0,0 +1,82 @@
+package main
+
+import (
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/holds"
+	"github.com/cstdev/moonapi/query"
+)
+
+// holdFilter parses the hold flags into a Filter, returning every invalid
+// flag rather than stopping at the first.
+func holdFilter(start string, end string, uses string, avoid string, minHolds int, maxHolds int) (holds.Filter, []error) {
+	filter := holds.Filter{MinHolds: minHolds, MaxHolds: maxHolds}
+	var errs []error
+
+	for _, flag := range []struct {
+		value string
+		into  *[]holds.Position
+	}{
+		{start, &filter.Start},
+		{end, &filter.End},
+		{uses, &filter.Uses},
+		{avoid, &filter.Avoid},
+	} {
+		positions, err := holds.ParsePositions(flag.value)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		*flag.into = positions
+	}
+
+	if len(errs) == 0 {
+		if err := filter.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return filter, errs
+}
+
+// holdsPage fetches every page of the query, keeps the problems whose holds
+// match the filter and returns the query's page of those, with Total
+// counting every match rather than every problem the query found.
+func holdsPage(api moonapi.MoonBoardApi, q query.Query, filter holds.Filter) (moonapi.MbResponse, error) {
+	res := moonapi.MbResponse{Data: []moonapi.Problem{}}
+
+	definition, err := query.DefinitionOf(q)
+	if err != nil {
+		return res, err
+	}
+	definition.PageSize = query.MaxPageSize
+
+	var matched []moonapi.Problem
+	for page := 1; ; page++ {
+		definition.Page = page
+		pageQuery, errs := query.FromDefinition(definition).Build()
+		if len(errs) > 0 {
+			return res, errs[0]
+		}
+
+		problems, err := api.GetProblems(pageQuery)
+		if err != nil {
+			return res, err
+		}
+		matched = append(matched, filter.Apply(problems.Data)...)
+
+		if len(problems.Data) < query.MaxPageSize || page*query.MaxPageSize >= problems.Total {
+			break
+		}
+	}
+
+	res.Total = len(matched)
+	start := (q.Page() - 1) * q.PageSize()
+	if start < 0 || start >= len(matched) {
+		return res, nil
+	}
+	end := start + q.PageSize()
+	if end > len(matched) {
+		end = len(matched)
+	}
+	res.Data = matched[start:end]
+	return res, nil
+}
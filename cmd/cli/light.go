@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/leds"
+	"github.com/cstdev/moonapi/query"
+)
+
+// lightCommand lights a downloaded problem on the board, or prints the
+// message that would light it if no device is given.
+// e.g. moonapi light -device /dev/rfcomm0 305445
+func lightCommand(args []string) {
+	flags := flag.NewFlagSet("light", flag.ExitOnError)
+	var dbPath = flags.String("db", "./problems.db", "Path of the local problem database.")
+	var inPath = flags.String("in", "", "Find the problem in a JSON file instead of the database.")
+	var device = flags.String("device", "", "Serial device to send the problem to, e.g. /dev/rfcomm0")
+	var address = flags.String("tcp", "", "Address of a TCP socket to send the problem to, e.g. 192.168.1.20:4000")
+	var outPath = flags.String("out", "", "File to write the message to. (default stdout)")
+	var flip = flags.Bool("flip", false, "The LED strip starts at the top of column A.")
+	var clear = flags.String("clear", "", "Send this message to turn every LED off instead of lighting a problem, it depends on the board's firmware.")
+	flags.Parse(args)
+
+	var problem moonapi.Problem
+	if *clear == "" {
+		id, err := strconv.Atoi(flags.Arg(0))
+		if err != nil {
+			fmt.Println("Enter the id of a problem to light, e.g. moonapi light 305445")
+			os.Exit(1)
+		}
+		problem, err = findProblem(loadProblems(*dbPath, *inPath), id)
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+	}
+	layout := leds.LayoutFor(query.Setup(problem.Holdsetup.Description), *flip)
+
+	err := light(problem, layout, *device, *address, *outPath, *clear)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+}
+
+// light sends the problem, or the clear message if one is given, to the
+// device, TCP address or file, or to stdout if none are given. Anything
+// opened is closed before returning.
+func light(problem moonapi.Problem, layout leds.Layout, device string, address string, outPath string, clear string) error {
+	var driver *leds.StreamDriver
+	var err error
+	switch {
+	case device != "":
+		driver, err = leds.OpenSerial(device, layout)
+	case address != "":
+		driver, err = leds.DialTCP(address, layout)
+	case outPath != "":
+		file, err := os.Create(outPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		driver = leds.NewWriter(file, layout)
+	default:
+		driver = leds.NewWriter(os.Stdout, layout)
+	}
+	if err != nil {
+		return err
+	}
+	defer driver.Close()
+
+	if clear != "" {
+		driver.SetClearMessage(clear)
+		return driver.Clear()
+	}
+	return driver.Show(problem)
+}
+
+// findProblem returns the problem with the given id.
+// errors if there isn't one
+func findProblem(problems []moonapi.Problem, id int) (moonapi.Problem, error) {
+	for _, problem := range problems {
+		if problem.ID == id {
+			return problem, nil
+		}
+	}
+	return moonapi.Problem{}, errors.New("Problem " + strconv.Itoa(id) + " not found")
+}
@@ -5,8 +5,13 @@ import (
 	"flag"
 	"fmt"
 	"io/ioutil"
+	"os"
+	"strconv"
 
 	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/offline"
+	"github.com/cstdev/moonapi/planner"
+	"github.com/cstdev/moonapi/store"
 	"github.com/cstdev/moonapi/utils"
 )
 
@@ -50,34 +55,89 @@ func reuseSession() moonapi.MoonBoard {
 	return moonBoardSession
 }
 
+// commands are run instead of querying when their name is the first
+// argument, each takes the arguments following its name.
+var commands = map[string]func(args []string){
+	"light":   lightCommand,
+	"search":  searchCommand,
+	"stats":   statsCommand,
+	"similar": similarCommand,
+}
+
 func main() {
-	var moonBoardSession = moonapi.MoonBoard{}
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			command(os.Args[2:])
+			return
+		}
+	}
 
 	var shouldLogin = flag.Bool("login", false, "Whether to log in or use cached credentials.")
 	var username = flag.String("user", "", "Enter a username to log in with.")
 	var password = flag.String("pass", "", "Enter a password to log in with.")
 
-	var order = flag.String("o", "", "Order to sort problems by: New, Grade, Rating, Repeats.")
-	var desc = flag.String("d", "true", "Sort by descending.")
+	var order = flag.String("o", "", "Orders to sort problems by split by comma, each optionally suffixed with -asc or -desc: New, Grade, Rating, Repeats, Name, Setter, DateInserted, UserRating.")
+	var desc = flag.String("d", "true", "Sort by descending: true or false.")
+	var setup = flag.String("s", "", "Board hold setup: 2016, 2017, 2019, 2020, Mini. (default any)")
 	var configuration = flag.String("c", "", "Board configuration: Forty, Twenty")
-	var holdSet = flag.String("hs", "", "Hold Set types to include split by comma: OS, Wood, A, B, C. (default all)")
+	var holdSet = flag.String("hs", "", "Hold Set types to include split by comma: OS, Wood, WoodB, WoodC, A, B, C, D, E. (default all)")
 	var filter = flag.String("f", "", "Filter to apply to problems: Benchmarks, Setbyme, Myascents")
 	var minGrade = flag.String("min", "", "Mininum grade to return.")
 	var maxGrade = flag.String("max", "", "Maximum grade to return.")
 	var page = flag.String("p", "", "Page number")
 	var pageSize = flag.String("ps", "", "Page size")
+	var plan = flag.Bool("plan", false, "Count the matching problems and estimate how long fetching them all would take.")
+	var rps = flag.Float64("rps", 1, "Requests per second allowed when estimating with -plan.")
+	var sync = flag.Bool("sync", false, "Store new and changed problems matching the query in the database at -db.")
+	var full = flag.Bool("full", false, "With -sync, fetch every page to pick up changes to older problems.")
+	var dbPath = flag.String("db", "./problems.db", "Path of the local problem database.")
+	var useDB = flag.Bool("offline", false, "Query the problems in the database at -db instead of the website.")
+	var inPath = flag.String("in", "", "Query the problems in a JSON file instead of the website.")
+	var start = flag.String("start", "", "Only show problems starting on these holds split by comma, e.g. F5,G2")
+	var end = flag.String("end", "", "Only show problems finishing on these holds split by comma.")
+	var uses = flag.String("uses", "", "Only show problems using these holds split by comma.")
+	var avoid = flag.String("avoid", "", "Only show problems not using any of these holds split by comma.")
+	var minHolds = flag.Int("minholds", 0, "Only show problems using at least this many holds.")
+	var maxHolds = flag.Int("maxholds", 0, "Only show problems using at most this many holds.")
+	var format = flag.String("format", "json", "Output format: json, board.")
+	var colour = flag.Bool("colour", false, "Colour the holds when using -format board.")
+	var unicode = flag.Bool("unicode", false, "Mark the holds with unicode symbols when using -format board.")
 
 	flag.Parse()
 
-	if *shouldLogin {
-		moonBoardSession = login(*username, *password)
+	descending, err := strconv.ParseBool(*desc)
+	if err != nil {
+		fmt.Printf("Invalid value '%s' for -d, must be true or false\n", *desc)
+		os.Exit(1)
+	}
+
+	if *format != "json" && *format != "board" {
+		fmt.Printf("Unknown format '%s', must be one of json, board\n", *format)
+		os.Exit(1)
+	}
+
+	holdsFilter, errs := holdFilter(*start, *end, *uses, *avoid, *minHolds, *maxHolds)
+	if len(errs) > 0 {
+		fmt.Println("Invalid holds:")
+		for _, err := range errs {
+			fmt.Printf("  %s\n", err.Error())
+		}
+		os.Exit(1)
+	}
+
+	var api moonapi.MoonBoardApi
+	if *useDB || *inPath != "" {
+		api = offline.New(loadProblems(*dbPath, *inPath))
+	} else if *shouldLogin {
+		api = login(*username, *password)
 	} else {
-		moonBoardSession = reuseSession()
+		api = reuseSession()
 	}
 
 	reqQuery := &utils.RequestQuery{
 		Order:         *order,
-		Asc:           *desc,
+		Asc:           strconv.FormatBool(!descending),
+		Setup:         *setup,
 		Configuration: *configuration,
 		HoldSet:       *holdSet,
 		Filter:        *filter,
@@ -88,14 +148,60 @@ func main() {
 	}
 
 	query, err := reqQuery.Query()
+	if validationErr, ok := err.(*utils.ValidationError); ok {
+		fmt.Println("Invalid query:")
+		for _, fieldErr := range validationErr.Errors {
+			fmt.Printf("  %s\n", fieldErr.Error())
+		}
+		os.Exit(1)
+	}
 	check(err)
 
 	fmt.Printf("%+v\n", query)
 
-	problems, err := moonBoardSession.GetProblems(query)
+	if *plan {
+		total, err := api.Count(query)
+		check(err)
+
+		estimate, err := planner.New(total, query.PageSize(), *rps)
+		check(err)
+
+		fmt.Printf("\n Number of Problems: %d\n Requests: %d of %d problems\n Estimated time: %s\n",
+			estimate.Total, estimate.Requests, estimate.PageSize, estimate.Estimated)
+		return
+	}
+
+	if *sync {
+		db, err := store.Open(*dbPath)
+		check(err)
+		defer db.Close()
+
+		var result store.SyncResult
+		if *full {
+			result, err = db.FullSync(api, query)
+		} else {
+			result, err = db.Sync(api, query)
+		}
+		check(err)
+
+		fmt.Printf("\n Added: %d\n Updated: %d\n Deleted: %d\n Pages fetched: %d\n",
+			result.Added, result.Updated, result.Deleted, result.Pages)
+		return
+	}
+
+	var problems moonapi.MbResponse
+	if holdsFilter.IsEmpty() {
+		problems, err = api.GetProblems(query)
+	} else {
+		problems, err = holdsPage(api, query, holdsFilter)
+	}
 	check(err)
 
 	fmt.Printf("\n\n Number of Problems: %d\n\n", problems.Total)
+	if *format == "board" {
+		printBoards(problems.Data, *colour, *unicode)
+		return
+	}
 	fmt.Println(moonapi.ProblemsAsJSON(problems.Data))
 
 }
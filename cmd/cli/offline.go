@@ -0,0 +1,31 @@
+package main
+
+import (
+	"os"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/offline"
+	"github.com/cstdev/moonapi/store"
+)
+
+// loadProblems reads every problem from the JSON dump at inPath, or from
+// the database at dbPath if no dump is given.
+func loadProblems(dbPath string, inPath string) []moonapi.Problem {
+	if inPath != "" {
+		file, err := os.Open(inPath)
+		check(err)
+		defer file.Close()
+
+		board, err := offline.Load(file)
+		check(err)
+		return board.Problems()
+	}
+
+	db, err := store.Open(dbPath)
+	check(err)
+	defer db.Close()
+
+	problems, err := db.All()
+	check(err)
+	return problems
+}
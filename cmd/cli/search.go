@@ -0,0 +1,35 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/cstdev/moonapi/search"
+)
+
+// searchCommand searches the names and setters of downloaded problems.
+// e.g. moonapi search -n 5 "pere noel"
+func searchCommand(args []string) {
+	flags := flag.NewFlagSet("search", flag.ExitOnError)
+	var dbPath = flags.String("db", "./problems.db", "Path of the local problem database.")
+	var inPath = flags.String("in", "", "Search the problems in a JSON file instead of the database.")
+	var limit = flags.Int("n", 20, "Maximum number of results, 0 for all.")
+	var edits = flags.Int("e", -1, "Typos allowed per word, -1 allows more in longer words.")
+	flags.Parse(args)
+
+	terms := strings.Join(flags.Args(), " ")
+	if terms == "" {
+		fmt.Println("Enter words to search for, e.g. moonapi search \"pere noel\"")
+		return
+	}
+
+	index := search.NewIndex(loadProblems(*dbPath, *inPath))
+	results := index.SearchWithOptions(terms, search.Options{MaxEdits: *edits, Limit: *limit})
+
+	fmt.Printf("\n Number of Problems: %d\n\n", len(results))
+	for _, result := range results {
+		fmt.Printf(" %6.2f  ", result.Score)
+		printProblem(result.Problem)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/similarity"
+)
+
+// similarCommand lists the downloaded problems most like a given problem,
+// or every group of duplicated problems.
+// e.g. moonapi similar -id 305445 -n 5
+func similarCommand(args []string) {
+	flags := flag.NewFlagSet("similar", flag.ExitOnError)
+	var dbPath = flags.String("db", "./problems.db", "Path of the local problem database.")
+	var inPath = flags.String("in", "", "Compare the problems in a JSON file instead of the database.")
+	var id = flags.Int("id", 0, "Id of the problem to find similar problems to.")
+	var limit = flags.Int("n", 10, "Maximum number of similar problems, 0 for all.")
+	var duplicates = flags.Bool("duplicates", false, "List every group of differently named problems using exactly the same holds.")
+	flags.Parse(args)
+
+	problems := loadProblems(*dbPath, *inPath)
+	engine := similarity.New(problems)
+
+	if *duplicates {
+		groups := engine.Duplicates()
+		fmt.Printf("\n Number of Duplicates: %d\n", len(groups))
+		for _, group := range groups {
+			fmt.Println()
+			for _, problem := range group {
+				printProblem(problem)
+			}
+		}
+		return
+	}
+
+	target, err := findProblem(problems, *id)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Print("\n Similar to: ")
+	printProblem(target)
+	fmt.Println()
+	for _, match := range engine.Similar(target, *limit) {
+		duplicate := ""
+		if match.Duplicate {
+			duplicate = "duplicate"
+		}
+		fmt.Printf(" %4.0f%% %-9s ", match.Score*100, duplicate)
+		printProblem(match.Problem)
+	}
+}
+
+// printProblem prints a one line summary of a problem.
+func printProblem(problem moonapi.Problem) {
+	benchmark := ""
+	if problem.IsBenchmark {
+		benchmark = "benchmark"
+	}
+	fmt.Printf("%-8d %-30s %-5s %-20s %s\n", problem.ID, problem.Name, problem.Grade, problem.Setter.Nickname, benchmark)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cstdev/moonapi/stats"
+)
+
+// report is written by the stats command in the chosen format.
+type report interface {
+	WriteTable(w io.Writer) error
+	WriteCSV(w io.Writer) error
+}
+
+// statsCommand summarises downloaded problems as a table, CSV or JSON.
+// e.g. moonapi stats -report setters -by repeats -n 10 -format csv
+func statsCommand(args []string) {
+	flags := flag.NewFlagSet("stats", flag.ExitOnError)
+	var dbPath = flags.String("db", "./problems.db", "Path of the local problem database.")
+	var inPath = flags.String("in", "", "Summarise the problems in a JSON file instead of the database.")
+	var kind = flags.String("report", "grades", "Report to show: grades, setters, holds, softness.")
+	var format = flags.String("format", "table", "Output format: table, csv, json.")
+	var by = flags.String("by", "problems", "Rank setters by: problems, repeats, rating.")
+	var limit = flags.Int("n", 20, "Number of setters to show, 0 for all.")
+	var threshold = flags.Int("threshold", stats.DefaultSoftnessOptions.Threshold, "Grades out a user grade must be to flag a problem as soft or sandbagged.")
+	var minRepeats = flags.Int("repeats", stats.DefaultSoftnessOptions.MinRepeats, "Repeats a problem needs before its user grade is trusted.")
+	flags.Parse(args)
+
+	problems := loadProblems(*dbPath, *inPath)
+
+	var out report
+	switch *kind {
+	case "grades":
+		out = stats.Grades(problems)
+	case "setters":
+		order, err := stats.ToSetterOrder(*by)
+		check(err)
+		out = stats.Setters(problems, order).Top(*limit)
+	case "holds":
+		out = stats.Holds(problems, stats.DefaultBands)
+	case "softness":
+		softness, err := stats.Softness(problems, stats.SoftnessOptions{Threshold: *threshold, MinRepeats: *minRepeats})
+		check(err)
+		out = softness
+	default:
+		fmt.Printf("Unknown report '%s', must be one of grades, setters, holds, softness\n", *kind)
+		os.Exit(1)
+	}
+
+	switch *format {
+	case "table":
+		check(out.WriteTable(os.Stdout))
+	case "csv":
+		check(out.WriteCSV(os.Stdout))
+	case "json":
+		jsonOut, err := json.MarshalIndent(out, "", "  ")
+		check(err)
+		fmt.Println(string(jsonOut))
+	default:
+		fmt.Printf("Unknown format '%s', must be one of table, csv, json\n", *format)
+		os.Exit(1)
+	}
+}
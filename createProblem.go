@@ -0,0 +1,80 @@
+package moonapi
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+)
+
+// The page and form problems are created with. These have not been checked
+// against a recording of the website: the page and form id follow the
+// pattern of the login form, Account/Login and #frmLogin, and the form is
+// filled in using the JSON names the website gives the fields of a Problem
+// in GetProblems responses. testdata/README.md describes the fixtures the
+// tests use and how to replace them with a recording.
+const (
+	createProblemUrl  = "Problems/Create"
+	createProblemForm = "#frmCreateProblem"
+)
+
+// problemViewUrl matches the page the website shows a problem on, e.g.
+// /Problems/View/305445/my-problem
+var problemViewUrl = regexp.MustCompile(`/Problems/View/(\d+)/([^/?#]+)`)
+
+// createdProblem identifies a problem created on the website.
+type createdProblem struct {
+	ID   int
+	Slug string
+}
+
+// createProblem submits a new problem to the website, returning its Id and
+// the slug used in its URL. Use NewProblem to build a problem that follows
+// the rules of the board before submitting it. It is not exported until the
+// form has been checked against a recording of the website.
+// It requires the session to provide the _MoonBoard AuthToken, the
+// __RequestVerificationToken is read from the form.
+// errors are returned if the session has expired, or a *RejectedError if
+// the website rejects the problem
+func (m MoonBoard) createProblem(p Problem) (createdProblem, error) {
+	var created createdProblem
+
+	holds, err := json.Marshal(p.Moves)
+	if err != nil {
+		return created, err
+	}
+
+	holdSetNames := p.HoldSetNames()
+	if holdSetNames == nil {
+		holdSetNames = []string{}
+	}
+	holdSets, err := json.Marshal(holdSetNames)
+	if err != nil {
+		return created, err
+	}
+
+	bow, err := m.submitForm(createProblemUrl, createProblemForm, "create problem", []formField{
+		{"Name", p.Name},
+		{"Grade", p.Grade},
+		{"Method", p.Method},
+		{"MoonBoardConfiguration", p.MoonBoardConfiguration.Description},
+		{"Holdsetup", p.Holdsetup.Description},
+		{"Holdsets", string(holdSets)},
+		{"Moves", string(holds)},
+	})
+	if err != nil {
+		return created, err
+	}
+
+	match := problemViewUrl.FindStringSubmatch(bow.Url().Path)
+	if match == nil {
+		if err := rejection(bow); err != nil {
+			return created, err
+		}
+		return created, errors.New("problem was not created, the website gave no reason")
+	}
+
+	created.ID, _ = strconv.Atoi(match[1])
+	created.Slug = match[2]
+	return created, nil
+}
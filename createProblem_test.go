@@ -0,0 +1,195 @@
+package moonapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/jarcoal/httpmock.v1"
+)
+
+// fixture returns the contents of a file in testdata, see
+// testdata/README.md for where they come from.
+func fixture(t *testing.T, name string) string {
+	content, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Errorf("Unable to read fixture %s: %s", name, err.Error())
+		t.FailNow()
+	}
+	return string(content)
+}
+
+func createProblemSession() MoonBoard {
+	var testAuth []AuthToken
+	testAuth = append(testAuth, *testMoonCookie)
+	testAuth = append(testAuth, *testReqCookie)
+	return MoonBoard{
+		auth: testAuth,
+	}
+}
+
+func testNewProblem() Problem {
+	problem := Problem{
+		Name:   "Test Problem",
+		Grade:  "6B+",
+		Method: FeetFollowHands,
+		Moves: []Move{
+			{Description: "F5", IsStart: true},
+			{Description: "G10"},
+			{Description: "F18", IsEnd: true},
+		},
+	}
+	problem.MoonBoardConfiguration.Description = "40° MoonBoard"
+	problem.Holdsetup.Description = "MoonBoard Masters 2017"
+	problem.Holdsets = []interface{}{"Wooden Holds", "Hold Set A"}
+	return problem
+}
+
+func TestCreateProblemReturnsIdAndSlug(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://moonboard.com/Problems/Create",
+		httpmock.NewStringResponder(200, fixture(t, "createProblem.html")))
+
+	var submitted http.Request
+	httpmock.RegisterResponder("POST", "https://moonboard.com/Problems/Create",
+		func(req *http.Request) (*http.Response, error) {
+			req.ParseForm()
+			submitted = *req
+			resp := httpmock.NewStringResponse(302, "")
+			resp.Header.Set("Location", "https://moonboard.com/Problems/View/305445/test-problem")
+			return resp, nil
+		},
+	)
+	httpmock.RegisterResponder("GET", "https://moonboard.com/Problems/View/305445/test-problem",
+		httpmock.NewStringResponder(200, "<html><body>Test Problem</body></html>"))
+
+	created, err := createProblemSession().createProblem(testNewProblem())
+	if err != nil {
+		t.Errorf("Expected problem to be created, recieved error: %s", err.Error())
+		t.FailNow()
+	}
+
+	if created.ID != 305445 {
+		t.Errorf("Expected Id 305445, got %d", created.ID)
+	}
+	if created.Slug != "test-problem" {
+		t.Errorf("Expected slug test-problem, got %s", created.Slug)
+	}
+
+	if submitted.PostForm.Get("__RequestVerificationToken") != "FormToken" {
+		t.Errorf("Expected verification token from the form to be submitted, got '%s'", submitted.PostForm.Get("__RequestVerificationToken"))
+	}
+	if submitted.PostForm.Get("Name") != "Test Problem" {
+		t.Errorf("Expected Name 'Test Problem', got '%s'", submitted.PostForm.Get("Name"))
+	}
+	if submitted.PostForm.Get("Grade") != "6B+" {
+		t.Errorf("Expected Grade 6B+, got '%s'", submitted.PostForm.Get("Grade"))
+	}
+	if submitted.PostForm.Get("Holdsetup") != "MoonBoard Masters 2017" {
+		t.Errorf("Expected Holdsetup 'MoonBoard Masters 2017', got '%s'", submitted.PostForm.Get("Holdsetup"))
+	}
+	if submitted.PostForm.Get("Holdsets") != `["hold set a","wooden holds"]` {
+		t.Errorf("Expected the problem's hold sets to be submitted, got '%s'", submitted.PostForm.Get("Holdsets"))
+	}
+
+	var moves []Move
+	if err := json.Unmarshal([]byte(submitted.PostForm.Get("Moves")), &moves); err != nil {
+		t.Errorf("Expected Moves to be JSON, recieved error: %s", err.Error())
+		t.FailNow()
+	}
+	if len(moves) != 3 || !moves[0].IsStart || !moves[2].IsEnd || moves[1].Description != "G10" {
+		t.Errorf("Expected the problem's moves to be submitted, got %+v", moves)
+	}
+}
+
+func TestCreateProblemRejectedReturnsTypedErrors(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://moonboard.com/Problems/Create",
+		httpmock.NewStringResponder(200, fixture(t, "createProblem.html")))
+	httpmock.RegisterResponder("POST", "https://moonboard.com/Problems/Create",
+		httpmock.NewStringResponder(200, fixture(t, "createProblemRejected.html")))
+
+	_, err := createProblemSession().createProblem(testNewProblem())
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+		t.FailNow()
+	}
+
+	rejected, ok := err.(*RejectedError)
+	if !ok {
+		t.Errorf("Expected a *RejectedError, got %T: %s", err, err.Error())
+		t.FailNow()
+	}
+
+	if len(rejected.Errors) != 3 {
+		t.Errorf("Expected 3 validation messages, got %d: %s", len(rejected.Errors), err.Error())
+		t.FailNow()
+	}
+	if !rejected.Has(NameTaken) {
+		t.Errorf("Expected the name to be taken: %s", err.Error())
+	}
+	if !rejected.Has(InvalidHolds) {
+		t.Errorf("Expected the holds to be invalid: %s", err.Error())
+	}
+	if rejected.Errors[0].Field != "Name" {
+		t.Errorf("Expected the first message to be for Name, got '%s'", rejected.Errors[0].Field)
+	}
+	if rejected.Errors[2].Field != "" || rejected.Errors[2].Kind != InvalidValue {
+		t.Errorf("Expected the summary message to be for the whole form, got %+v", rejected.Errors[2])
+	}
+}
+
+func TestCreateProblemOnExpiredSessionReturnsError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://moonboard.com/Problems/Create",
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(302, "")
+			resp.Header.Set("Location", "https://moonboard.com/Account/Login?ReturnUrl=%2FProblems%2FCreate")
+			return resp, nil
+		},
+	)
+	httpmock.RegisterResponder("GET", "https://moonboard.com/Account/Login",
+		httpmock.NewStringResponder(200, loginForm))
+
+	_, err := createProblemSession().createProblem(testNewProblem())
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+		t.FailNow()
+	}
+	if err.Error() != "session expired, please log in" {
+		t.Errorf("Expected session expired error, got: %s", err.Error())
+	}
+}
+
+func TestCreateProblemWithoutAuthReturnsError(t *testing.T) {
+	_, err := MoonBoard{}.createProblem(testNewProblem())
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+	}
+}
+
+func TestCreateProblemMissingFormFieldReturnsError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://moonboard.com/Problems/Create",
+		httpmock.NewStringResponder(200, `<html><body><form id="frmCreateProblem" method="post" action="/Problems/Create">
+<input name="Name" type="text" value="" /></form></body></html>`))
+
+	_, err := createProblemSession().createProblem(testNewProblem())
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+		t.FailNow()
+	}
+	if err.Error() != "create problem form is missing the Grade field" {
+		t.Errorf("Expected missing Grade field error, got: %s", err.Error())
+	}
+}
@@ -7,8 +7,10 @@ require (
 	github.com/headzoo/surf v1.0.0
 	github.com/mongodb/mongo-go-driver v1.0.2 // indirect
 	github.com/sirupsen/logrus v1.4.1
+	go.etcd.io/bbolt v1.3.5
 	golang.org/x/net v0.0.0-20180417003750-8d16fa6dc9a8
 	golang.org/x/text v0.3.0
 	gopkg.in/headzoo/surf.v1 v1.0.0
 	gopkg.in/jarcoal/httpmock.v1 v1.0.0-20180424175138-eb84b840d3d6
+	gopkg.in/yaml.v2 v2.4.0
 )
@@ -0,0 +1,106 @@
+package holds
+
+import (
+	"errors"
+
+	"github.com/cstdev/moonapi"
+)
+
+// Filter matches problems by the holds they use.
+// Start and End holds must be start or finishing holds of the problem,
+// Uses holds can be anywhere in it and Avoid holds must not be used at all.
+// MinHolds and MaxHolds bound the number of holds used, 0 is no limit.
+type Filter struct {
+	Start    []Position
+	End      []Position
+	Uses     []Position
+	Avoid    []Position
+	MinHolds int
+	MaxHolds int
+}
+
+// IsEmpty returns true if the Filter matches every problem.
+func (f Filter) IsEmpty() bool {
+	return len(f.Start) == 0 && len(f.End) == 0 && len(f.Uses) == 0 && len(f.Avoid) == 0 &&
+		f.MinHolds == 0 && f.MaxHolds == 0
+}
+
+// Validate checks the Filter can match a problem.
+// errors if the hold counts are negative or the wrong way round, or a hold
+// is both required and avoided
+func (f Filter) Validate() error {
+	if f.MinHolds < 0 || f.MaxHolds < 0 {
+		return errors.New("hold counts cannot be negative")
+	}
+	if f.MaxHolds > 0 && f.MinHolds > f.MaxHolds {
+		return errors.New("minimum hold count is greater than the maximum")
+	}
+
+	for _, avoid := range f.Avoid {
+		for _, required := range [][]Position{f.Start, f.End, f.Uses} {
+			if contains(required, avoid) {
+				return errors.New("hold " + avoid.String() + " cannot be both used and avoided")
+			}
+		}
+	}
+	return nil
+}
+
+// Match returns true if the problem uses holds matching the Filter.
+func (f Filter) Match(problem moonapi.Problem) bool {
+	holds := Of(problem)
+
+	used := map[Position]Hold{}
+	for _, hold := range holds {
+		used[hold.Position] = hold
+	}
+
+	if f.MinHolds > 0 && len(used) < f.MinHolds {
+		return false
+	}
+	if f.MaxHolds > 0 && len(used) > f.MaxHolds {
+		return false
+	}
+
+	for _, position := range f.Start {
+		if hold, ok := used[position]; !ok || !hold.IsStart {
+			return false
+		}
+	}
+	for _, position := range f.End {
+		if hold, ok := used[position]; !ok || !hold.IsEnd {
+			return false
+		}
+	}
+	for _, position := range f.Uses {
+		if _, ok := used[position]; !ok {
+			return false
+		}
+	}
+	for _, position := range f.Avoid {
+		if _, ok := used[position]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply returns the problems matching the Filter, in their original order.
+func (f Filter) Apply(problems []moonapi.Problem) []moonapi.Problem {
+	matched := []moonapi.Problem{}
+	for _, problem := range problems {
+		if f.Match(problem) {
+			matched = append(matched, problem)
+		}
+	}
+	return matched
+}
+
+func contains(positions []Position, position Position) bool {
+	for _, p := range positions {
+		if p == position {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package holds
+
+import (
+	"testing"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/internal/problemtest"
+)
+
+func testProblems() []moonapi.Problem {
+	return []moonapi.Problem{
+		problemtest.Problem(1, []string{"F5", "G2"}, []string{"K12", "H14"}, []string{"E18"}),
+		problemtest.Problem(2, []string{"F5"}, []string{"K12", "E15"}, []string{"A18"}),
+		problemtest.Problem(3, []string{"G2"}, []string{"F5"}, []string{"E18"}),
+	}
+}
+
+func positions(t *testing.T, list string) []Position {
+	parsed, err := ParsePositions(list)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+	return parsed
+}
+
+func TestFilterStart(t *testing.T) {
+	filter := Filter{Start: positions(t, "F5,G2")}
+
+	got := problemtest.IDs(filter.Apply(testProblems()))
+	if len(got) != 1 || got[0] != 1 {
+		t.Errorf("Expected only problem 1 to start on F5 and G2, got %v", got)
+	}
+}
+
+func TestFilterUsesAndAvoid(t *testing.T) {
+	filter := Filter{Uses: positions(t, "K12"), Avoid: positions(t, "E15")}
+
+	got := problemtest.IDs(filter.Apply(testProblems()))
+	if len(got) != 1 || got[0] != 1 {
+		t.Errorf("Expected only problem 1 to use K12 but not E15, got %v", got)
+	}
+}
+
+func TestFilterUsesMatchesAnyHold(t *testing.T) {
+	filter := Filter{Uses: positions(t, "F5")}
+
+	got := problemtest.IDs(filter.Apply(testProblems()))
+	if len(got) != 3 {
+		t.Errorf("Expected every problem to use F5, got %v", got)
+	}
+}
+
+func TestFilterEnd(t *testing.T) {
+	filter := Filter{End: positions(t, "E18")}
+
+	got := problemtest.IDs(filter.Apply(testProblems()))
+	if len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Errorf("Expected problems 1 and 3 to finish on E18, got %v", got)
+	}
+}
+
+func TestFilterHoldCount(t *testing.T) {
+	filter := Filter{MinHolds: 4, MaxHolds: 4}
+
+	got := problemtest.IDs(filter.Apply(testProblems()))
+	if len(got) != 1 || got[0] != 2 {
+		t.Errorf("Expected only problem 2 to use 4 holds, got %v", got)
+	}
+}
+
+func TestFilterValidate(t *testing.T) {
+	if err := (Filter{MinHolds: 5, MaxHolds: 4}).Validate(); err == nil {
+		t.Errorf("Expected an error when min is greater than max")
+	}
+
+	if err := (Filter{Uses: positions(t, "K12"), Avoid: positions(t, "K12")}).Validate(); err == nil {
+		t.Errorf("Expected an error when a hold is used and avoided")
+	}
+
+	if err := (Filter{Start: positions(t, "F5"), MaxHolds: 8}).Validate(); err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+	}
+}
+
+func TestFilterIsEmpty(t *testing.T) {
+	if !(Filter{}).IsEmpty() {
+		t.Errorf("Expected a zero Filter to be empty")
+	}
+	if (Filter{MaxHolds: 3}).IsEmpty() {
+		t.Errorf("Expected a Filter with a hold count not to be empty")
+	}
+}
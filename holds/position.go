@@ -0,0 +1,71 @@
+// Package holds works with the holds a problem uses, given as board
+// positions such as "F5", and filters problems by them.
+package holds
+
+import (
+	"strings"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/query"
+)
+
+// The size of a full MoonBoard and the number of rows on a Mini MoonBoard,
+// see query.Columns.
+const (
+	Columns  = query.Columns
+	Rows     = query.Rows
+	MiniRows = query.MiniRows
+)
+
+// RowsFor returns the number of rows on the board for a hold setup.
+func RowsFor(setup query.Setup) int {
+	return query.RowsFor(setup)
+}
+
+// Position is a hold on the board, see query.Position.
+type Position = query.Position
+
+// ParsePosition parses a board position such as "F5" or "k18".
+// errors if the column or row is not on the board
+func ParsePosition(s string) (Position, error) {
+	return query.ParsePosition(s)
+}
+
+// ParsePositions parses a comma separated list of board positions, an
+// empty list gives no positions.
+func ParsePositions(list string) ([]Position, error) {
+	var positions []Position
+	for _, s := range strings.Split(list, ",") {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		position, err := ParsePosition(s)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, position)
+	}
+	return positions, nil
+}
+
+// Hold is a position used by a problem and whether it is a start or
+// finishing hold.
+type Hold struct {
+	Position
+	IsStart bool
+	IsEnd   bool
+}
+
+// Of returns the holds a problem uses, in the order of its Moves.
+// Moves that aren't a valid board position are skipped.
+func Of(problem moonapi.Problem) []Hold {
+	var holds []Hold
+	for _, move := range problem.Moves {
+		position, err := ParsePosition(move.Description)
+		if err != nil {
+			continue
+		}
+		holds = append(holds, Hold{Position: position, IsStart: move.IsStart, IsEnd: move.IsEnd})
+	}
+	return holds
+}
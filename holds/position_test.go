@@ -0,0 +1,36 @@
+package holds
+
+import (
+	"testing"
+
+	"github.com/cstdev/moonapi"
+)
+
+func TestParsePositions(t *testing.T) {
+	positions, err := ParsePositions("F5, g2,")
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if len(positions) != 2 || positions[0].String() != "F5" || positions[1].String() != "G2" {
+		t.Errorf("Expected F5 and G2, got %v", positions)
+	}
+
+	if _, err := ParsePositions("F5,Z2"); err == nil {
+		t.Errorf("Expected an error for an invalid position")
+	}
+}
+
+func TestOfSkipsInvalidMoves(t *testing.T) {
+	problem := moonapi.Problem{Moves: []moonapi.Move{
+		{Description: "F5", IsStart: true},
+		{Description: "??"},
+		{Description: "K18", IsEnd: true},
+	}}
+
+	holds := Of(problem)
+	if len(holds) != 2 || !holds[0].IsStart || !holds[1].IsEnd || holds[1].String() != "K18" {
+		t.Errorf("Expected start F5 and end K18, got %+v", holds)
+	}
+}
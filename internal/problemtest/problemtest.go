@@ -0,0 +1,40 @@
+// Package problemtest builds problems for the tests of the packages that
+// work on downloaded problems.
+package problemtest
+
+import "github.com/cstdev/moonapi"
+
+// Problem creates a problem starting on start, finishing on end and using
+// the middle holds in between. Any other values are left for the test to set.
+func Problem(id int, start []string, middle []string, end []string) moonapi.Problem {
+	problem := moonapi.Problem{ID: id}
+	for _, hold := range start {
+		problem.Moves = append(problem.Moves, moonapi.Move{Description: hold, IsStart: true})
+	}
+	for _, hold := range middle {
+		problem.Moves = append(problem.Moves, moonapi.Move{Description: hold})
+	}
+	for _, hold := range end {
+		problem.Moves = append(problem.Moves, moonapi.Move{Description: hold, IsEnd: true})
+	}
+	return problem
+}
+
+// HoldSets returns the named hold sets in the shape the website gives the
+// Holdsets of a problem.
+func HoldSets(names ...string) []interface{} {
+	holdSets := []interface{}{}
+	for _, name := range names {
+		holdSets = append(holdSets, map[string]interface{}{"Description": name})
+	}
+	return holdSets
+}
+
+// IDs returns the Id of each problem, in order.
+func IDs(problems []moonapi.Problem) []int {
+	var out []int
+	for _, problem := range problems {
+		out = append(out, problem.ID)
+	}
+	return out
+}
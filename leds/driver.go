@@ -0,0 +1,120 @@
+package leds
+
+import (
+	"errors"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"github.com/cstdev/moonapi"
+)
+
+// Driver lights the holds of a problem on a board.
+type Driver interface {
+	// Show lights the holds of the problem, replacing any already lit.
+	Show(problem moonapi.Problem) error
+	// Clear turns every LED off.
+	// errors if the board's message for turning every LED off is not known
+	Clear() error
+}
+
+// packetDelay is the pause between packets sent to a serial device or
+// over TCP, giving a Bluetooth bridge time to pass each one on.
+const packetDelay = 20 * time.Millisecond
+
+// dialTimeout is how long DialTCP waits to connect.
+const dialTimeout = 5 * time.Second
+
+// StreamDriver is a Driver writing messages to a stream, such as a serial
+// device, a TCP connection or a file.
+type StreamDriver struct {
+	w      io.Writer
+	closer io.Closer
+	layout Layout
+	// lines writes each message whole on its own line instead of in
+	// packets.
+	lines bool
+	delay time.Duration
+	clear string
+}
+
+var _ Driver = &StreamDriver{}
+
+// NewWriter creates a Driver writing each message on its own line, for
+// printing to stdout or a file while testing without a board.
+func NewWriter(w io.Writer, layout Layout) *StreamDriver {
+	return &StreamDriver{w: w, layout: layout, lines: true}
+}
+
+// OpenSerial creates a Driver writing to a serial device such as
+// /dev/rfcomm0 or /dev/ttyUSB0. The device must already be set to the
+// right baud rate, e.g. with stty.
+func OpenSerial(path string, layout Layout) (*StreamDriver, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &StreamDriver{w: file, closer: file, layout: layout, delay: packetDelay}, nil
+}
+
+// DialTCP creates a Driver sending to a TCP socket, such as a Bluetooth
+// bridge listening on the network, e.g. 192.168.1.20:4000.
+func DialTCP(address string, layout Layout) (*StreamDriver, error) {
+	conn, err := net.DialTimeout("tcp", address, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &StreamDriver{w: conn, closer: conn, layout: layout, delay: packetDelay}, nil
+}
+
+// Show sends the message lighting the problem's holds.
+// errors if the problem has no holds or a hold is not on the board
+func (d *StreamDriver) Show(problem moonapi.Problem) error {
+	message, err := Encode(problem, d.layout)
+	if err != nil {
+		return err
+	}
+	return d.send(message)
+}
+
+// SetClearMessage sets the message Clear sends. The LED system has no
+// documented message for turning every LED off, so it depends on the
+// firmware of the board.
+func (d *StreamDriver) SetClearMessage(message string) {
+	d.clear = message
+}
+
+// Clear sends the message set with SetClearMessage.
+// errors if no message has been set
+func (d *StreamDriver) Clear() error {
+	if d.clear == "" {
+		return errors.New("no message for turning the LEDs off has been set")
+	}
+	return d.send(d.clear)
+}
+
+// Close closes the device or connection, if the Driver opened one.
+func (d *StreamDriver) Close() error {
+	if d.closer == nil {
+		return nil
+	}
+	return d.closer.Close()
+}
+
+func (d *StreamDriver) send(message string) error {
+	if d.lines {
+		_, err := io.WriteString(d.w, message+"\n")
+		return err
+	}
+
+	for i, packet := range Packets(message) {
+		if i > 0 && d.delay > 0 {
+			time.Sleep(d.delay)
+		}
+		if _, err := io.WriteString(d.w, packet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package leds
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+)
+
+func TestWriterDriver(t *testing.T) {
+	var buffer bytes.Buffer
+	driver := NewWriter(&buffer, Standard)
+	driver.SetClearMessage("l#X#")
+
+	if err := driver.Show(testProblem()); err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+	}
+	if err := driver.Clear(); err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+	}
+
+	if buffer.String() != "l#S0,P103,E197#\nl#X#\n" {
+		t.Errorf("Expected the message then the clear message, got %q", buffer.String())
+	}
+
+	if err := driver.Close(); err != nil {
+		t.Errorf("Expected closing a writer to do nothing, got %s", err.Error())
+	}
+}
+
+func TestClearWithoutMessageErrors(t *testing.T) {
+	var buffer bytes.Buffer
+	driver := NewWriter(&buffer, Standard)
+
+	if err := driver.Clear(); err == nil {
+		t.Errorf("Expected an error clearing without a clear message")
+	}
+	if buffer.Len() != 0 {
+		t.Errorf("Expected nothing to be written, got %q", buffer.String())
+	}
+}
+
+func TestWriterDriverInvalidProblem(t *testing.T) {
+	var buffer bytes.Buffer
+	driver := NewWriter(&buffer, Mini)
+
+	if err := driver.Show(testProblem()); err == nil {
+		t.Errorf("Expected an error for K18 on the mini board")
+	}
+	if buffer.Len() != 0 {
+		t.Errorf("Expected nothing to be written, got %q", buffer.String())
+	}
+}
+
+func TestSerialDriver(t *testing.T) {
+	file, err := ioutil.TempFile("", "leds")
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+	file.Close()
+	defer os.Remove(file.Name())
+
+	driver, err := OpenSerial(file.Name(), Standard)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+	driver.Show(testProblem())
+	driver.Close()
+
+	written, _ := ioutil.ReadFile(file.Name())
+	if string(written) != "l#S0,P103,E197#" {
+		t.Errorf("Expected the message without a newline, got %q", string(written))
+	}
+}
+
+func TestSerialDriverMissingDevice(t *testing.T) {
+	if _, err := OpenSerial("/no/such/device", Standard); err == nil {
+		t.Errorf("Expected an error opening a missing device")
+	}
+}
+
+func TestTCPDriver(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+	defer listener.Close()
+
+	received := make(chan string)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			received <- ""
+			return
+		}
+		data, _ := ioutil.ReadAll(conn)
+		received <- string(data)
+	}()
+
+	driver, err := DialTCP(listener.Addr().String(), Standard)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+	driver.SetClearMessage("l#X#")
+	driver.Show(testProblem())
+	driver.Clear()
+	driver.Close()
+
+	if got := <-received; got != "l#S0,P103,E197#l#X#" {
+		t.Errorf("Expected the message then the clear message, got %q", got)
+	}
+}
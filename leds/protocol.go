@@ -0,0 +1,181 @@
+// Package leds lights problems on a board fitted with the MoonBoard LED
+// system, which takes the holds of a problem as a short text message sent
+// over Bluetooth.
+package leds
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/holds"
+	"github.com/cstdev/moonapi/query"
+)
+
+// PacketSize is the most bytes the LED system reads in one Bluetooth
+// write, longer messages are sent as several packets.
+const PacketSize = 20
+
+// The start and end of a message, and the letters marking start, move and
+// finish holds in it.
+const (
+	messageStart = "l#"
+	messageEnd   = "#"
+	startMark    = 'S'
+	moveMark     = 'P'
+	endMark      = 'E'
+)
+
+// Layout describes how the LED strip is wired behind the board. The strip
+// snakes up column A, down column B and so on. Flip is for boards wired
+// the other way up, with the strip starting at the top of column A.
+type Layout struct {
+	Rows int
+	Flip bool
+}
+
+// Layouts for the full size and mini boards.
+var (
+	Standard    = Layout{Rows: holds.Rows}
+	Flipped     = Layout{Rows: holds.Rows, Flip: true}
+	Mini        = Layout{Rows: holds.MiniRows}
+	MiniFlipped = Layout{Rows: holds.MiniRows, Flip: true}
+)
+
+// LayoutFor returns the layout of a board with the given hold setup.
+func LayoutFor(setup query.Setup, flip bool) Layout {
+	return Layout{Rows: holds.RowsFor(setup), Flip: flip}
+}
+
+// LEDs returns the number of LEDs on the strip.
+func (l Layout) LEDs() int {
+	return holds.Columns * l.Rows
+}
+
+// Index returns the position of the LED for a hold along the strip.
+// errors if the hold is not on the board
+func (l Layout) Index(p holds.Position) (int, error) {
+	if p.Column < 0 || p.Column >= holds.Columns || p.Row < 1 || p.Row > l.Rows {
+		return 0, fmt.Errorf("hold %s is not on a board with %d rows", p, l.Rows)
+	}
+
+	fromBottom := p.Column%2 == 0
+	if l.Flip {
+		fromBottom = !fromBottom
+	}
+	if fromBottom {
+		return p.Column*l.Rows + p.Row - 1, nil
+	}
+	return p.Column*l.Rows + l.Rows - p.Row, nil
+}
+
+// Position returns the hold lit by the LED at index along the strip.
+// errors if there is no such LED
+func (l Layout) Position(index int) (holds.Position, error) {
+	if index < 0 || index >= l.LEDs() {
+		return holds.Position{}, fmt.Errorf("LED %d is not on a board with %d LEDs", index, l.LEDs())
+	}
+
+	column, offset := index/l.Rows, index%l.Rows
+	fromBottom := column%2 == 0
+	if l.Flip {
+		fromBottom = !fromBottom
+	}
+	if fromBottom {
+		return holds.Position{Column: column, Row: offset + 1}, nil
+	}
+	return holds.Position{Column: column, Row: l.Rows - offset}, nil
+}
+
+// Encode returns the message lighting the holds of a problem, e.g.
+// l#S69,P93,E140#
+// errors if the problem has no holds or a hold is not on the board
+func Encode(problem moonapi.Problem, layout Layout) (string, error) {
+	return EncodeHolds(holds.Of(problem), layout)
+}
+
+// EncodeHolds returns the message lighting the holds, in the order given.
+// A hold that is both a start and a finish is sent as a start.
+// errors if there are no holds or a hold is not on the board
+func EncodeHolds(problemHolds []holds.Hold, layout Layout) (string, error) {
+	if len(problemHolds) == 0 {
+		return "", errors.New("no holds to light")
+	}
+
+	parts := make([]string, 0, len(problemHolds))
+	for _, hold := range problemHolds {
+		index, err := layout.Index(hold.Position)
+		if err != nil {
+			return "", err
+		}
+
+		mark := moveMark
+		switch {
+		case hold.IsStart:
+			mark = startMark
+		case hold.IsEnd:
+			mark = endMark
+		}
+		parts = append(parts, string(mark)+strconv.Itoa(index))
+	}
+	return messageStart + strings.Join(parts, ",") + messageEnd, nil
+}
+
+// Decode reads the holds back out of a message.
+// errors if the message is not in the expected format, has no holds or
+// lights an LED that is not on the board
+func Decode(message string, layout Layout) ([]holds.Hold, error) {
+	if !strings.HasPrefix(message, messageStart) || !strings.HasSuffix(message, messageEnd) || len(message) < len(messageStart)+len(messageEnd) {
+		return nil, errors.New("message must start with " + messageStart + " and end with " + messageEnd)
+	}
+
+	body := message[len(messageStart) : len(message)-len(messageEnd)]
+	if body == "" {
+		return nil, errors.New("message has no holds")
+	}
+
+	var decoded []holds.Hold
+	for _, part := range strings.Split(body, ",") {
+		if len(part) < 2 {
+			return nil, errors.New("invalid hold '" + part + "' in message")
+		}
+
+		index, err := strconv.Atoi(part[1:])
+		if err != nil {
+			return nil, errors.New("invalid LED '" + part[1:] + "' in message")
+		}
+		position, err := layout.Position(index)
+		if err != nil {
+			return nil, err
+		}
+
+		hold := holds.Hold{Position: position}
+		switch part[0] {
+		case startMark:
+			hold.IsStart = true
+		case endMark:
+			hold.IsEnd = true
+		case moveMark:
+		default:
+			return nil, fmt.Errorf("invalid hold type '%c' in message, must be %c, %c or %c", part[0], startMark, moveMark, endMark)
+		}
+		decoded = append(decoded, hold)
+	}
+	return decoded, nil
+}
+
+// Packets splits a message into the packets it is sent as, each at most
+// PacketSize bytes long. Joining the packets gives back the message.
+func Packets(message string) []string {
+	var packets []string
+	for len(message) > PacketSize {
+		packets = append(packets, message[:PacketSize])
+		message = message[PacketSize:]
+	}
+	if message != "" {
+		packets = append(packets, message)
+	}
+	return packets
+}
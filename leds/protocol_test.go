@@ -0,0 +1,163 @@
+package leds
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/holds"
+	"github.com/cstdev/moonapi/query"
+)
+
+func position(t *testing.T, s string) holds.Position {
+	p, err := holds.ParsePosition(s)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+	return p
+}
+
+func testProblem() moonapi.Problem {
+	return moonapi.Problem{Moves: []moonapi.Move{
+		{Description: "A1", IsStart: true},
+		{Description: "F5"},
+		{Description: "K18", IsEnd: true},
+	}}
+}
+
+func TestIndex(t *testing.T) {
+	cases := []struct {
+		layout   Layout
+		hold     string
+		expected int
+	}{
+		{Standard, "A1", 0},
+		{Standard, "A18", 17},
+		{Standard, "B18", 18},
+		{Standard, "B1", 35},
+		{Standard, "F5", 103},
+		{Standard, "K18", 197},
+		{Flipped, "A18", 0},
+		{Flipped, "A1", 17},
+		{Flipped, "B1", 18},
+		{Mini, "A12", 11},
+		{Mini, "F5", 67},
+		{MiniFlipped, "A12", 0},
+	}
+
+	for _, c := range cases {
+		index, err := c.layout.Index(position(t, c.hold))
+		if err != nil {
+			t.Errorf("Unexpected error. %s", err.Error())
+			continue
+		}
+		if index != c.expected {
+			t.Errorf("Expected %s to be LED %d with %+v, got %d", c.hold, c.expected, c.layout, index)
+		}
+	}
+}
+
+func TestIndexOffBoard(t *testing.T) {
+	if _, err := Mini.Index(position(t, "A13")); err == nil {
+		t.Errorf("Expected an error for A13 on the mini board")
+	}
+}
+
+func TestPositionReversesIndex(t *testing.T) {
+	for _, layout := range []Layout{Standard, Flipped, Mini, MiniFlipped} {
+		for index := 0; index < layout.LEDs(); index++ {
+			p, err := layout.Position(index)
+			if err != nil {
+				t.Errorf("Unexpected error. %s", err.Error())
+				t.FailNow()
+			}
+			if back, _ := layout.Index(p); back != index {
+				t.Errorf("Expected LED %d to map back to itself with %+v, got %d", index, layout, back)
+			}
+		}
+
+		if _, err := layout.Position(layout.LEDs()); err == nil {
+			t.Errorf("Expected an error for an LED past the end of the strip")
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	message, err := Encode(testProblem(), Standard)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if message != "l#S0,P103,E197#" {
+		t.Errorf("Expected l#S0,P103,E197#, got %s", message)
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	if _, err := Encode(moonapi.Problem{}, Standard); err == nil {
+		t.Errorf("Expected an error for a problem with no holds")
+	}
+	if _, err := Encode(testProblem(), Mini); err == nil {
+		t.Errorf("Expected an error for K18 on the mini board")
+	}
+}
+
+func TestDecode(t *testing.T) {
+	decoded, err := Decode("l#S0,P103,E197#", Standard)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	expected := holds.Of(testProblem())
+	if len(decoded) != len(expected) {
+		t.Errorf("Expected %v, got %v", expected, decoded)
+		t.FailNow()
+	}
+	for i := range expected {
+		if decoded[i] != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], decoded[i])
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, message := range []string{"", "#", "l##", "S1,P2", "l#S1,X2#", "l#S#", "l#,#", "l#Sx#", "l#P198#"} {
+		if _, err := Decode(message, Standard); err == nil {
+			t.Errorf("Expected an error decoding '%s'", message)
+		}
+	}
+}
+
+func TestPackets(t *testing.T) {
+	problem := testProblem()
+	for _, hold := range []string{"B4", "C7", "D9", "E11", "G13", "H15"} {
+		problem.Moves = append(problem.Moves, moonapi.Move{Description: hold})
+	}
+	message, _ := Encode(problem, Standard)
+
+	packets := Packets(message)
+	if len(packets) != 3 {
+		t.Errorf("Expected %d bytes to take 3 packets, got %d", len(message), len(packets))
+	}
+	for _, packet := range packets {
+		if len(packet) > PacketSize {
+			t.Errorf("Expected packets of at most %d bytes, got %d", PacketSize, len(packet))
+		}
+	}
+	if strings.Join(packets, "") != message {
+		t.Errorf("Expected the packets to join back into the message")
+	}
+
+	if len(Packets("")) != 0 {
+		t.Errorf("Expected no packets for an empty message")
+	}
+}
+
+func TestLayoutFor(t *testing.T) {
+	if LayoutFor(query.Mini2020, true) != MiniFlipped || LayoutFor(query.Masters2017, false) != Standard {
+		t.Errorf("Expected the mini and standard layouts")
+	}
+}
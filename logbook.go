@@ -0,0 +1,213 @@
+package moonapi
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/golang/glog"
+)
+
+// The pages of a user's logbook. These have not been checked against a
+// recording of the website, testdata/README.md describes where the page and
+// its markup come from.
+const logbookUrl = "Logbook/Index/"
+
+// logbookDateFormat is the format the logbook shows the date climbed in,
+// when the time climbed isn't given in a datetime attribute.
+const logbookDateFormat = "02 Jan 2006"
+
+// maxLogbookPages stops a logbook that keeps linking to a next page from
+// being fetched forever.
+const maxLogbookPages = 500
+
+var leadingNumber = regexp.MustCompile(`\d+`)
+
+// LogbookEntry is an ascent of a problem from a user's logbook. Problem
+// holds the Id, name and slug of the problem climbed, Grade is the grade the
+// user gave it and Rating the stars they rated it.
+type LogbookEntry struct {
+	Problem Problem
+	Date    time.Time
+	Tries   int
+	Grade   string
+	Rating  int
+	Comment string
+}
+
+// LogbookSession is every ascent logged on a single day.
+type LogbookSession struct {
+	Date    time.Time
+	Entries []LogbookEntry
+}
+
+// Logbook is a user's ascents grouped into sessions, most recent first.
+type Logbook []LogbookSession
+
+// Entries returns the ascents of every session, most recent first.
+func (l Logbook) Entries() []LogbookEntry {
+	var entries []LogbookEntry
+	for _, session := range l {
+		entries = append(entries, session.Entries...)
+	}
+	return entries
+}
+
+// GetLogbook returns the ascents logged by user between from and to,
+// inclusive of both days, grouped into sessions by the date climbed. A zero
+// from or to leaves that end of the range open. Every page of the logbook
+// is fetched until the ascents are older than from. Ascents without a
+// problem or date climbed are skipped.
+// It requires the session to provide the _MoonBoard AuthToken.
+// errors are returned if the session has expired or a page can't be read
+func (m MoonBoard) GetLogbook(user string, from time.Time, to time.Time) (Logbook, error) {
+	if user == "" {
+		return nil, errors.New("user is required")
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return nil, errors.New("to must not be before from")
+	}
+
+	bow, err := m.browser()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []LogbookEntry
+	for page := 1; page <= maxLogbookPages; page++ {
+		err = bow.Open(baseUrl + logbookUrl + url.PathEscape(user) + "?page=" + strconv.Itoa(page))
+		if err != nil {
+			return nil, err
+		}
+		if err := checkResponse(bow); err != nil {
+			return nil, err
+		}
+
+		pageEntries := logbookEntries(bow.Dom())
+
+		older := false
+		for _, entry := range pageEntries {
+			if !from.IsZero() && entry.Date.Before(day(from)) {
+				older = true
+				continue
+			}
+			if !to.IsZero() && day(entry.Date).After(day(to)) {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+
+		if older || bow.Find(".logbook-entry").Length() == 0 || bow.Find(".pagination .next a").Length() == 0 {
+			break
+		}
+	}
+
+	return sessions(entries), nil
+}
+
+// logbookEntries reads the ascents from a page of a logbook, skipping any
+// without a problem or a date climbed so one bad ascent doesn't lose the
+// rest of the logbook.
+func logbookEntries(dom *goquery.Selection) []LogbookEntry {
+	var entries []LogbookEntry
+	dom.Find(".logbook-entry").Each(func(_ int, s *goquery.Selection) {
+		entry, err := logbookEntry(s)
+		if err != nil {
+			glog.Infof("Skipping logbook ascent: %s", err.Error())
+			return
+		}
+		entries = append(entries, entry)
+	})
+	return entries
+}
+
+// logbookEntry reads a single ascent from a logbook.
+// errors if the problem or date climbed can't be read
+func logbookEntry(s *goquery.Selection) (LogbookEntry, error) {
+	var entry LogbookEntry
+
+	link := s.Find("a.problem")
+	href, _ := link.Attr("href")
+	match := problemViewUrl.FindStringSubmatch(href)
+	if match == nil {
+		return entry, errors.New("unable to read logbook problem from '" + href + "'")
+	}
+	entry.Problem.ID, _ = strconv.Atoi(match[1])
+	entry.Problem.NameForURL = match[2]
+	entry.Problem.Name = strings.TrimSpace(link.Text())
+
+	date, err := logbookDate(s.Find(".date"))
+	if err != nil {
+		return entry, err
+	}
+	entry.Date = date
+
+	entry.Tries = tries(s.Find(".tries").Text())
+	entry.Grade = strings.TrimSpace(s.Find(".grade").Text())
+	entry.Rating, _ = strconv.Atoi(strings.TrimSpace(s.Find(".rating").Text()))
+	entry.Comment = strings.TrimSpace(s.Find(".comment").Text())
+	return entry, nil
+}
+
+// logbookDate reads the time an ascent was climbed from the datetime
+// attribute of its date, falling back to the day shown.
+// errors if neither can be read
+func logbookDate(s *goquery.Selection) (time.Time, error) {
+	if datetime, ok := s.Attr("datetime"); ok {
+		date, err := time.Parse(time.RFC3339, datetime)
+		if err != nil {
+			return date, errors.New("invalid logbook date: " + datetime)
+		}
+		return date.UTC(), nil
+	}
+
+	text := strings.TrimSpace(s.Text())
+	date, err := time.Parse(logbookDateFormat, text)
+	if err != nil {
+		return date, errors.New("invalid logbook date: " + text)
+	}
+	return date, nil
+}
+
+// tries reads the attempts taken from text such as "Flashed" or "3 tries",
+// returning 0 if it can't be read.
+func tries(text string) int {
+	text = strings.TrimSpace(text)
+	if strings.EqualFold(text, "flashed") {
+		return 1
+	}
+	n, _ := strconv.Atoi(leadingNumber.FindString(text))
+	return n
+}
+
+// day returns the start of the day t is on.
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// sessions groups entries by the day they were climbed, most recent first,
+// keeping the order of the entries within each day.
+func sessions(entries []LogbookEntry) Logbook {
+	var logbook Logbook
+	index := map[time.Time]int{}
+	for _, entry := range entries {
+		date := day(entry.Date)
+		i, ok := index[date]
+		if !ok {
+			i = len(logbook)
+			index[date] = i
+			logbook = append(logbook, LogbookSession{Date: date})
+		}
+		logbook[i].Entries = append(logbook[i].Entries, entry)
+	}
+
+	sort.SliceStable(logbook, func(i, j int) bool {
+		return logbook[i].Date.After(logbook[j].Date)
+	})
+	return logbook
+}
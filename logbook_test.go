@@ -0,0 +1,208 @@
+package moonapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"gopkg.in/jarcoal/httpmock.v1"
+)
+
+func registerLogbookPages(pages map[string]string, requested *[]string) {
+	httpmock.RegisterResponder("GET", "https://moonboard.com/Logbook/Index/TestUser",
+		func(req *http.Request) (*http.Response, error) {
+			page := req.URL.Query().Get("page")
+			*requested = append(*requested, page)
+			body, ok := pages[page]
+			if !ok {
+				body = "<html><body></body></html>"
+			}
+			resp := httpmock.NewStringResponse(200, body)
+			resp.Request = req
+			return resp, nil
+		},
+	)
+}
+
+func logbookPages(t *testing.T) map[string]string {
+	return map[string]string{
+		"1": fixture(t, "logbookPageOne.html"),
+		"2": fixture(t, "logbookPageTwo.html"),
+	}
+}
+
+func TestGetLogbookFetchesEveryPageAndGroupsSessions(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var requested []string
+	registerLogbookPages(logbookPages(t), &requested)
+
+	logbook, err := createProblemSession().GetLogbook("TestUser", time.Time{}, time.Time{})
+	if err != nil {
+		t.Errorf("Expected logbook, recieved error: %s", err.Error())
+		t.FailNow()
+	}
+
+	if len(requested) != 2 {
+		t.Errorf("Expected 2 pages to be fetched, got %v", requested)
+	}
+
+	if len(logbook) != 3 {
+		t.Errorf("Expected 3 sessions, got %d", len(logbook))
+		t.FailNow()
+	}
+	if !logbook[0].Date.Equal(time.Date(2020, 3, 14, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the most recent session first, got %s", logbook[0].Date)
+	}
+	if len(logbook[0].Entries) != 2 {
+		t.Errorf("Expected 2 ascents in the first session, got %d", len(logbook[0].Entries))
+	}
+	if len(logbook[2].Entries) != 1 || logbook[2].Entries[0].Problem.ID != 3 {
+		t.Errorf("Expected the last session to hold problem 3 from the second page, got %+v", logbook[2].Entries)
+	}
+
+	entry := logbook[0].Entries[0]
+	if entry.Problem.ID != 305445 || entry.Problem.Name != "Test Problem" || entry.Problem.NameForURL != "test-problem" {
+		t.Errorf("Expected problem 305445 Test Problem, got %d %s %s", entry.Problem.ID, entry.Problem.Name, entry.Problem.NameForURL)
+	}
+	if entry.Tries != 1 {
+		t.Errorf("Expected a flash to be 1 try, got %d", entry.Tries)
+	}
+	if entry.Grade != "6C+" {
+		t.Errorf("Expected grade 6C+, got %s", entry.Grade)
+	}
+	if entry.Rating != 2 {
+		t.Errorf("Expected rating 2, got %d", entry.Rating)
+	}
+	if entry.Comment != "Tricky start" {
+		t.Errorf("Expected comment 'Tricky start', got '%s'", entry.Comment)
+	}
+	if logbook[0].Entries[1].Tries != 4 {
+		t.Errorf("Expected 4 tries, got %d", logbook[0].Entries[1].Tries)
+	}
+
+	if len(logbook.Entries()) != 4 {
+		t.Errorf("Expected 4 entries, got %d", len(logbook.Entries()))
+	}
+}
+
+func TestGetLogbookFiltersByDate(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var requested []string
+	registerLogbookPages(logbookPages(t), &requested)
+
+	from := time.Date(2020, 3, 10, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2020, 3, 12, 18, 30, 0, 0, time.UTC)
+	logbook, err := createProblemSession().GetLogbook("TestUser", from, to)
+	if err != nil {
+		t.Errorf("Expected logbook, recieved error: %s", err.Error())
+		t.FailNow()
+	}
+
+	if len(logbook) != 1 || len(logbook[0].Entries) != 1 || logbook[0].Entries[0].Problem.ID != 2 {
+		t.Errorf("Expected only the ascent of problem 2, got %+v", logbook)
+	}
+}
+
+func TestGetLogbookStopsAtAscentsOlderThanFrom(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var requested []string
+	registerLogbookPages(logbookPages(t), &requested)
+
+	from := time.Date(2020, 3, 12, 0, 0, 0, 0, time.UTC)
+	logbook, err := createProblemSession().GetLogbook("TestUser", from, time.Time{})
+	if err != nil {
+		t.Errorf("Expected logbook, recieved error: %s", err.Error())
+		t.FailNow()
+	}
+
+	if len(requested) != 1 {
+		t.Errorf("Expected only the first page to be fetched, got %v", requested)
+	}
+	if len(logbook) != 1 || len(logbook[0].Entries) != 2 {
+		t.Errorf("Expected the 2 ascents on 14 Mar 2020, got %+v", logbook)
+	}
+}
+
+func TestGetLogbookIncludesAscentsLaterOnTheToDay(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var requested []string
+	registerLogbookPages(logbookPages(t), &requested)
+
+	from := time.Date(2020, 3, 11, 0, 0, 0, 0, time.UTC)
+	logbook, err := createProblemSession().GetLogbook("TestUser", from, from)
+	if err != nil {
+		t.Errorf("Expected logbook, recieved error: %s", err.Error())
+		t.FailNow()
+	}
+
+	entries := logbook.Entries()
+	if len(entries) != 1 || entries[0].Problem.ID != 2 {
+		t.Errorf("Expected the ascent of problem 2 climbed that evening, got %+v", entries)
+		t.FailNow()
+	}
+	if !entries[0].Date.Equal(time.Date(2020, 3, 11, 19, 45, 0, 0, time.UTC)) {
+		t.Errorf("Expected the time climbed to be read, got %s", entries[0].Date)
+	}
+}
+
+func TestGetLogbookSkipsUnreadableAscents(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var requested []string
+	registerLogbookPages(map[string]string{"1": `<html><body>
+<div class="logbook-entry"><a class="problem" href="/Problems/View/1/one">One</a><span class="date">yesterday</span></div>
+<div class="logbook-entry"><a class="problem" href="/Problems/View/2/two">Two</a><time class="date" datetime="last week">Last week</time></div>
+<div class="logbook-entry"><a class="problem" href="/Problems">Missing</a><span class="date">14 Mar 2020</span></div>
+<div class="logbook-entry"><a class="problem" href="/Problems/View/3/three">Three</a><span class="date">14 Mar 2020</span><span class="tries">3 tries</span></div>
+</body></html>`}, &requested)
+
+	logbook, err := createProblemSession().GetLogbook("TestUser", time.Time{}, time.Time{})
+	if err != nil {
+		t.Errorf("Expected logbook, recieved error: %s", err.Error())
+		t.FailNow()
+	}
+
+	entries := logbook.Entries()
+	if len(entries) != 1 || entries[0].Problem.ID != 3 || entries[0].Tries != 3 {
+		t.Errorf("Expected only the ascent of problem 3, got %+v", entries)
+	}
+}
+
+func TestGetLogbookRequiresUser(t *testing.T) {
+	_, err := createProblemSession().GetLogbook("", time.Time{}, time.Time{})
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+	}
+}
+
+func TestGetLogbookToBeforeFromReturnsError(t *testing.T) {
+	from := time.Date(2020, 3, 10, 0, 0, 0, 0, time.UTC)
+	_, err := createProblemSession().GetLogbook("TestUser", from, from.AddDate(0, 0, -1))
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+	}
+}
+
+func TestTries(t *testing.T) {
+	tests := map[string]int{
+		"Flashed":     1,
+		"2nd try":     2,
+		" 5 tries ":   5,
+		"more than 3": 3,
+		"":            0,
+	}
+	for text, expected := range tests {
+		if got := tries(text); got != expected {
+			t.Errorf("Expected '%s' to be %d tries, got %d", text, expected, got)
+		}
+	}
+}
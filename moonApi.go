@@ -5,14 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"net/http/cookiejar"
 	"net/url"
 	"strconv"
 	"strings"
 
 	. "github.com/cstdev/moonapi/query"
 	"github.com/golang/glog"
-	"golang.org/x/net/publicsuffix"
 	"gopkg.in/headzoo/surf.v1"
 )
 
@@ -24,9 +22,12 @@ type AuthToken struct {
 }
 
 // MoonBoardApi provides methods for interacting with the website
+// It covers reading problems, which offline.Board can stand in for, while
+// the methods that need a member's account are only found on MoonBoard.
 type MoonBoardApi interface {
 	Login(username string, password string) error
 	GetProblems(query Query) (MbResponse, error)
+	Count(query Query) (int, error)
 	Auth() []AuthToken
 	SetAuth(authTokens []AuthToken)
 }
@@ -113,45 +114,18 @@ func (m MoonBoard) GetProblems(query Query) (MbResponse, error) {
 
 	res := MbResponse{}
 
-	jar, _ := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
-	var cookies []*http.Cookie
-
-	if len(m.auth) == 0 {
-		return res, errors.New("Required _Moonboard or __RequestVerificationToken Auth Tokens missing")
-	}
-
-	containsAuth := false
-	for _, token := range m.auth {
-		if token.Name == "_MoonBoard" {
-			containsAuth = true
-			break
-		}
-	}
-
-	if !containsAuth {
-		return res, errors.New("Required _Moonboard or __RequestVerificationToken Auth Tokens missing")
-	}
-
-	cookies = append(cookies, tokenToCookie(m.auth[0]))
-	cookies = append(cookies, tokenToCookie(m.auth[1]))
-	u, _ := url.Parse(baseUrl)
-	jar.SetCookies(u, cookies)
-	bow := surf.NewBrowser()
-	bow.SetCookieJar(jar)
-
-	err := bow.PostForm(baseUrl+getProblemsUrl, v)
-
+	bow, err := m.browser()
 	if err != nil {
 		return res, err
 	}
 
-	if strings.Contains(bow.Url().String(), "/Account/Login") {
-		//fmt.Println("Session Exprired")
-		return res, errors.New("session expired, please log in")
+	err = bow.PostForm(baseUrl+getProblemsUrl, v)
+	if err != nil {
+		return res, err
 	}
 
-	if bow.StatusCode() != 200 {
-		return res, errors.New("Server returned error status: " + strconv.Itoa(bow.StatusCode()))
+	if err := checkResponse(bow); err != nil {
+		return res, err
 	}
 	response := strings.Replace(bow.Body(), "&#34;", "\"", -1)
 	//fmt.Printf("Response: %v \n", response)
@@ -167,6 +141,30 @@ func (m MoonBoard) GetProblems(query Query) (MbResponse, error) {
 
 }
 
+// Count returns the number of problems that match the Query passed in.
+// Only a single problem is requested from the website, the page and page
+// size of the Query are ignored.
+func (m MoonBoard) Count(query Query) (int, error) {
+	res, err := m.GetProblems(firstProblem{query})
+	if err != nil {
+		return 0, err
+	}
+	return res.Total, nil
+}
+
+// firstProblem wraps a Query to request a page holding only its first problem.
+type firstProblem struct {
+	Query
+}
+
+func (q firstProblem) Page() int {
+	return 1
+}
+
+func (q firstProblem) PageSize() int {
+	return 1
+}
+
 func (m MoonBoard) Auth() []AuthToken {
 	return m.auth
 }
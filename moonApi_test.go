@@ -416,3 +416,46 @@ func TestIfJSONConversionFailsErrorIsReturned(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestCountRequestsASingleProblem(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	expectedString := `filter=Benchmarks~eq~%27%27~and~MinGrade~eq~%276A%2B%27~and~MaxGrade~eq~%278B%2B%27&group=&page=1&pageSize=1&sort=`
+
+	httpmock.RegisterResponder("POST", "https://moonboard.com/Problems/GetProblems",
+		func(req *http.Request) (*http.Response, error) {
+			buf := new(bytes.Buffer)
+			buf.ReadFrom(req.Body)
+
+			if buf.String() != expectedString {
+				t.Errorf("Request did not contain expected body. Got %s\n Expected %s", buf.String(), expectedString)
+			}
+
+			resp := httpmock.NewStringResponse(200, `{"Data":[], "Total":1234}`)
+			resp.Request = req
+			return resp, nil
+		},
+	)
+
+	var testAuth []AuthToken
+	testAuth = append(testAuth, *testMoonCookie)
+	testAuth = append(testAuth, *testReqCookie)
+	var session = MoonBoard{
+		auth: testAuth,
+	}
+
+	builder := query.New()
+	q, _ := builder.Filter(query.Benchmarks).MinGrade(query.SixAPlus).Page(3).PageSize(50).Build()
+
+	total, err := session.Count(q)
+	if err != nil {
+		t.Errorf("Error recieved: %v", err)
+		t.FailNow()
+	}
+
+	expected := 1234
+	if total != expected {
+		t.Errorf("Expected a total of %d, got %d", expected, total)
+	}
+}
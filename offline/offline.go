@@ -0,0 +1,262 @@
+// Package offline runs queries against problems that have already been
+// downloaded, so code can switch between the website and a local copy.
+package offline
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/query"
+	"github.com/cstdev/moonapi/store"
+)
+
+// Board implements moonapi.MoonBoardApi over a fixed set of problems.
+// Login and the auth tokens are accepted but not needed.
+type Board struct {
+	problems []moonapi.Problem
+	auth     []moonapi.AuthToken
+}
+
+var _ moonapi.MoonBoardApi = &Board{}
+
+// New creates a Board that runs queries against the problems passed in.
+func New(problems []moonapi.Problem) *Board {
+	return &Board{problems: problems}
+}
+
+// Load creates a Board from a JSON dump of problems, either a list of
+// problems as written by moonapi.ProblemsAsJSON or a GetProblems response.
+func Load(r io.Reader) (*Board, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []moonapi.Problem
+	if err := json.Unmarshal(data, &problems); err == nil {
+		return New(problems), nil
+	}
+
+	var res moonapi.MbResponse
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	return New(res.Data), nil
+}
+
+// FromStore creates a Board holding every problem in the store that has
+// not been deleted.
+func FromStore(s *store.Store) (*Board, error) {
+	problems, err := s.All()
+	if err != nil {
+		return nil, err
+	}
+	return New(problems), nil
+}
+
+// Problems returns every problem held by the Board.
+func (b *Board) Problems() []moonapi.Problem {
+	return b.problems
+}
+
+// Login does nothing, no session is needed to query an offline Board.
+func (b *Board) Login(username string, password string) error {
+	return nil
+}
+
+// GetProblems returns the page of problems matching the query in the same
+// shape as the website would.
+func (b *Board) GetProblems(q query.Query) (moonapi.MbResponse, error) {
+	definition, err := query.DefinitionOf(q)
+	if err != nil {
+		return moonapi.MbResponse{}, err
+	}
+	matched, err := Evaluate(b.problems, definition)
+	if err != nil {
+		return moonapi.MbResponse{}, err
+	}
+
+	res := moonapi.MbResponse{Total: len(matched), Data: []moonapi.Problem{}}
+	start := (q.Page() - 1) * q.PageSize()
+	if start < 0 || start >= len(matched) {
+		return res, nil
+	}
+	end := start + q.PageSize()
+	if end > len(matched) {
+		end = len(matched)
+	}
+	res.Data = matched[start:end]
+	return res, nil
+}
+
+// Count returns the number of problems matching the query.
+func (b *Board) Count(q query.Query) (int, error) {
+	definition, err := query.DefinitionOf(q)
+	if err != nil {
+		return 0, err
+	}
+	matched, err := Evaluate(b.problems, definition)
+	return len(matched), err
+}
+
+func (b *Board) Auth() []moonapi.AuthToken {
+	return b.auth
+}
+
+func (b *Board) SetAuth(authTokens []moonapi.AuthToken) {
+	b.auth = authTokens
+}
+
+// Evaluate returns every problem matching the Definition, sorted by its sort
+// orders. Paging is not applied.
+// errors if the Definition uses a filter that needs the logged in user
+func Evaluate(problems []moonapi.Problem, d query.Definition) ([]moonapi.Problem, error) {
+	benchmarks := false
+	for _, filter := range d.Filters {
+		if filter != query.Benchmarks {
+			return nil, errors.New("filter " + string(filter) + " cannot be evaluated offline")
+		}
+		benchmarks = true
+	}
+
+	grades := d.Grades()
+	term := strings.ToLower(d.Term)
+
+	matched := []moonapi.Problem{}
+	for _, problem := range problems {
+		if benchmarks && !problem.IsBenchmark {
+			continue
+		}
+		if term != "" && !strings.Contains(strings.ToLower(problem.Name), term) {
+			continue
+		}
+		if d.Setup != "" && problem.Holdsetup.Description != string(d.Setup) {
+			continue
+		}
+		if !matchesConfiguration(problem, d.Configurations) {
+			continue
+		}
+		if !matchesHoldSets(problem, d.HoldSets) {
+			continue
+		}
+
+		var grade query.Grade
+		if err := grade.UnmarshalText([]byte(problem.Grade)); err != nil {
+			continue
+		}
+		if grade < grades.Min || grade > grades.Max {
+			continue
+		}
+
+		matched = append(matched, problem)
+	}
+
+	keys := d.Sort
+	if len(keys) == 0 {
+		keys = []query.SortKey{{Order: query.Newest, Asc: false}}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		for _, key := range keys {
+			c := compare(matched[i], matched[j], key.Order)
+			if c == 0 {
+				continue
+			}
+			if key.Asc {
+				return c < 0
+			}
+			return c > 0
+		}
+		return false
+	})
+
+	return matched, nil
+}
+
+func matchesConfiguration(problem moonapi.Problem, configs []query.Configuration) bool {
+	if len(configs) == 0 {
+		return true
+	}
+	for _, config := range configs {
+		if problem.MoonBoardConfiguration.Description == string(config) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesHoldSets checks every hold set the problem uses is one of those
+// requested. Problems that don't say which hold sets they use always match.
+func matchesHoldSets(problem moonapi.Problem, holdSets []query.HoldSet) bool {
+	if len(holdSets) == 0 {
+		return true
+	}
+	for _, name := range problem.HoldSetNames() {
+		found := false
+		for _, holdSet := range holdSets {
+			if name == string(holdSet) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// compare returns a negative number if a sorts before b in ascending order
+// of the given Order, a positive number if after and 0 if they are equal.
+func compare(a moonapi.Problem, b moonapi.Problem, order query.Order) int {
+	switch order {
+	case query.Newest, query.DateInserted:
+		return compareTimes(a.Inserted, b.Inserted)
+	case query.Difficulty:
+		return compareInts(int(gradeOf(a)), int(gradeOf(b)))
+	case query.Rating:
+		return compareInts(a.Rating, b.Rating)
+	case query.UserRating:
+		return compareInts(a.UserRating, b.UserRating)
+	case query.Repeats:
+		return compareInts(a.Repeats, b.Repeats)
+	case query.Name:
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	case query.SetterName:
+		return strings.Compare(strings.ToLower(a.Setter.Nickname), strings.ToLower(b.Setter.Nickname))
+	}
+	return 0
+}
+
+func gradeOf(problem moonapi.Problem) query.Grade {
+	var grade query.Grade
+	grade.UnmarshalText([]byte(problem.Grade))
+	return grade
+}
+
+func compareInts(a int, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareTimes(a func() (time.Time, error), b func() (time.Time, error)) int {
+	timeA, _ := a()
+	timeB, _ := b()
+	switch {
+	case timeA.Before(timeB):
+		return -1
+	case timeA.After(timeB):
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,156 @@
+package offline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/internal/problemtest"
+	"github.com/cstdev/moonapi/query"
+)
+
+func testProblem(id int, name string, grade string, inserted string) moonapi.Problem {
+	problem := problemtest.Problem(id, nil, nil, nil)
+	problem.Name = name
+	problem.Grade = grade
+	problem.DateInserted = inserted
+	problem.MoonBoardConfiguration.Description = string(query.Forty)
+	problem.Holdsetup.Description = string(query.Masters2017)
+	return problem
+}
+
+func testProblems() []moonapi.Problem {
+	soft := testProblem(1, "SOFT WOOD RH", "7B", "/Date(1524237072990)/")
+	soft.IsBenchmark = true
+	soft.Repeats = 19
+	soft.Holdsets = problemtest.HoldSets("Wooden Holds")
+
+	crimp := testProblem(2, "Crimp City", "6B+", "/Date(1524237080000)/")
+	crimp.Repeats = 120
+	crimp.Holdsets = problemtest.HoldSets("Hold Set A", "Hold Set B")
+
+	twenty := testProblem(3, "Lazy Sunday", "6A", "/Date(1524237090000)/")
+	twenty.MoonBoardConfiguration.Description = string(query.Twenty)
+	twenty.Repeats = 50
+
+	hard := testProblem(4, "Soft Shoe Shuffle", "8A", "/Date(1524237070000)/")
+	hard.IsBenchmark = true
+	hard.Repeats = 2
+
+	return []moonapi.Problem{soft, crimp, twenty, hard}
+}
+
+func compareIDs(t *testing.T, actual []moonapi.Problem, expected ...int) {
+	got := problemtest.IDs(actual)
+	if len(got) != len(expected) {
+		t.Errorf("Expected problems %v, got %v", expected, got)
+		t.FailNow()
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Expected problems %v, got %v", expected, got)
+			t.FailNow()
+		}
+	}
+}
+
+func build(t *testing.T, builder query.QueryBuilder) query.Query {
+	q, errs := builder.Build()
+	if errs != nil {
+		t.Errorf("Unexpected error. %s", errs[0].Error())
+		t.FailNow()
+	}
+	return q
+}
+
+func TestDefaultQueryReturnsNewestFirst(t *testing.T) {
+	board := New(testProblems())
+	res, err := board.GetProblems(build(t, query.New()))
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	compareIDs(t, res.Data, 3, 2, 1, 4)
+	if res.Total != 4 {
+		t.Errorf("Expected a total of 4, got %d", res.Total)
+	}
+}
+
+func TestTermAndBenchmarksFilter(t *testing.T) {
+	board := New(testProblems())
+	res, _ := board.GetProblems(build(t, query.New().Term("soft").Filter(query.Benchmarks).Sort(query.Difficulty, true)))
+
+	compareIDs(t, res.Data, 1, 4)
+}
+
+func TestConfigurationAndGradeFilter(t *testing.T) {
+	board := New(testProblems())
+	res, _ := board.GetProblems(build(t, query.New().Configuration(query.Forty).MaxGrade(query.SevenB)))
+
+	compareIDs(t, res.Data, 2, 1)
+}
+
+func TestHoldSetFilter(t *testing.T) {
+	board := New(testProblems())
+	res, _ := board.GetProblems(build(t, query.New().HoldSet(query.A).HoldSet(query.B).Sort(query.Repeats, false)))
+
+	compareIDs(t, res.Data, 2, 3, 4)
+}
+
+func TestSortByMultipleKeys(t *testing.T) {
+	problems := testProblems()
+	problems[3].Grade = "7B"
+	board := New(problems)
+	res, _ := board.GetProblems(build(t, query.New().Sort(query.Difficulty, false).Sort(query.Name, true)))
+
+	compareIDs(t, res.Data, 4, 1, 2, 3)
+}
+
+func TestPaging(t *testing.T) {
+	board := New(testProblems())
+	res, _ := board.GetProblems(build(t, query.New().PageSize(3).Page(2)))
+
+	compareIDs(t, res.Data, 4)
+	if res.Total != 4 {
+		t.Errorf("Expected a total of 4, got %d", res.Total)
+	}
+}
+
+func TestUserFiltersCannotBeEvaluated(t *testing.T) {
+	board := New(testProblems())
+	_, err := board.GetProblems(build(t, query.New().Filter(query.MyAscents)))
+
+	expectedError := "filter Myascents cannot be evaluated offline"
+	if err == nil || err.Error() != expectedError {
+		t.Errorf("Incorrect error provided. Got: %v Expected: %s", err, expectedError)
+	}
+}
+
+func TestLoadReadsProblemsAsJSON(t *testing.T) {
+	data, _ := moonapi.ProblemsAsJSON(testProblems()[:2])
+
+	board, err := Load(strings.NewReader(data))
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	count, _ := board.Count(build(t, query.New()))
+	if count != 2 {
+		t.Errorf("Expected 2 problems, got %d", count)
+	}
+}
+
+func TestLoadReadsGetProblemsResponse(t *testing.T) {
+	board, err := Load(strings.NewReader(`{"Data":[{"Id":1,"Name":"A","Grade":"7A","DateInserted":"/Date(1524237072990)/"}],"Total":1}`))
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	count, _ := board.Count(build(t, query.New()))
+	if count != 1 {
+		t.Errorf("Expected 1 problem, got %d", count)
+	}
+}
@@ -0,0 +1,127 @@
+// Package planner estimates how many requests it takes to fetch every
+// problem matching a query and splits large queries into smaller ones that
+// can be fetched in parallel.
+package planner
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/cstdev/moonapi/query"
+)
+
+// Counter returns the number of problems that match a query, it is
+// satisfied by moonapi.MoonBoard.
+type Counter interface {
+	Count(query query.Query) (int, error)
+}
+
+// Plan describes the requests needed to fetch every problem matching a query.
+type Plan struct {
+	Total     int
+	PageSize  int
+	Requests  int
+	Estimated time.Duration
+}
+
+// New creates a Plan for fetching total problems in pages of pageSize,
+// making no more than requestsPerSecond requests each second.
+// errors if the page size is outside of 1 to query.MaxPageSize or the rate
+// is not positive
+func New(total int, pageSize int, requestsPerSecond float64) (Plan, error) {
+	if pageSize > query.MaxPageSize || pageSize < 1 {
+		return Plan{}, errors.New("page size must be between 1 and " + strconv.Itoa(query.MaxPageSize))
+	}
+	if requestsPerSecond <= 0 {
+		return Plan{}, errors.New("requests per second must be above 0")
+	}
+
+	requests := int(math.Ceil(float64(total) / float64(pageSize)))
+	seconds := float64(requests) / requestsPerSecond
+
+	return Plan{
+		Total:     total,
+		PageSize:  pageSize,
+		Requests:  requests,
+		Estimated: time.Duration(seconds * float64(time.Second)),
+	}, nil
+}
+
+// Estimate counts the problems matching the query built by the builder and
+// creates a Plan for fetching them.
+func Estimate(api Counter, builder query.QueryBuilder, pageSize int, requestsPerSecond float64) (Plan, error) {
+	q, errs := builder.Build()
+	if len(errs) > 0 {
+		return Plan{}, errs[0]
+	}
+
+	total, err := api.Count(q)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	return New(total, pageSize, requestsPerSecond)
+}
+
+// SplitByGrade splits the query built by the builder into at most buckets
+// queries, each covering a consecutive band of its grade range. Between them
+// the queries match the same problems as the original.
+// errors if the builder's query can't be built
+func SplitByGrade(builder query.QueryBuilder, buckets int) ([]query.QueryBuilder, error) {
+	if buckets < 1 {
+		return nil, errors.New("number of buckets must be at least 1")
+	}
+
+	q, errs := builder.Build()
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	definition, err := query.DefinitionOf(q)
+	if err != nil {
+		return nil, err
+	}
+	grades := definition.Grades()
+	if grades.Min > grades.Max {
+		return nil, errors.New("min grade cannot be higher than max grade")
+	}
+
+	count := int(grades.Max-grades.Min) + 1
+	if buckets > count {
+		buckets = count
+	}
+
+	var out []query.QueryBuilder
+	min := grades.Min
+	for i := 0; i < buckets; i++ {
+		size := count / buckets
+		if i < count%buckets {
+			size++
+		}
+		max := min + query.Grade(size-1)
+
+		bucket := definition
+		bucketMin, bucketMax := min, max
+		bucket.MinGrade = &bucketMin
+		bucket.MaxGrade = &bucketMax
+		out = append(out, query.FromDefinition(bucket))
+
+		min = max + 1
+	}
+	return out, nil
+}
+
+// EstimateSplit counts the problems in each query and creates a Plan for
+// each, so the size of every bucket from SplitByGrade can be checked.
+func EstimateSplit(api Counter, builders []query.QueryBuilder, pageSize int, requestsPerSecond float64) ([]Plan, error) {
+	var plans []Plan
+	for _, builder := range builders {
+		plan, err := Estimate(api, builder, pageSize, requestsPerSecond)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
@@ -0,0 +1,135 @@
+package planner
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cstdev/moonapi/query"
+)
+
+type fakeCounter struct {
+	total   int
+	err     error
+	queries []query.Query
+}
+
+func (c *fakeCounter) Count(q query.Query) (int, error) {
+	c.queries = append(c.queries, q)
+	return c.total, c.err
+}
+
+func TestNewPlan(t *testing.T) {
+	plan, err := New(1001, 100, 2)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if plan.Requests != 11 {
+		t.Errorf("Requests was incorrect, got %d, want: %d", plan.Requests, 11)
+	}
+
+	expected := 5500 * time.Millisecond
+	if plan.Estimated != expected {
+		t.Errorf("Estimated was incorrect, got %s, want: %s", plan.Estimated, expected)
+	}
+}
+
+func TestNewPlanErrorsOnBadPageSize(t *testing.T) {
+	_, err := New(10, 150, 1)
+	if err == nil {
+		t.Error("Expected error not recieved")
+	}
+}
+
+func TestNewPlanErrorsOnBadRate(t *testing.T) {
+	_, err := New(10, 15, 0)
+	if err == nil {
+		t.Error("Expected error not recieved")
+	}
+}
+
+func TestEstimateCountsQuery(t *testing.T) {
+	counter := &fakeCounter{total: 45}
+	plan, err := Estimate(counter, query.New().Filter(query.Benchmarks), 15, 1)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if plan.Total != 45 || plan.Requests != 3 || plan.Estimated != 3*time.Second {
+		t.Errorf("Plan was incorrect, got %+v", plan)
+	}
+
+	expected := "Benchmarks~eq~''~and~MinGrade~eq~'5+'~and~MaxGrade~eq~'8B+'"
+	if counter.queries[0].Filter() != expected {
+		t.Errorf("Counted query was incorrect, got %s, want: %s", counter.queries[0].Filter(), expected)
+	}
+}
+
+func TestEstimateReturnsCountError(t *testing.T) {
+	counter := &fakeCounter{err: errors.New("session expired, please log in")}
+	_, err := Estimate(counter, query.New(), 15, 1)
+	if err == nil {
+		t.Error("Expected error not recieved")
+	}
+}
+
+func TestSplitByGrade(t *testing.T) {
+	expected := []string{
+		"Configuration~eq~'40° MoonBoard'~and~MinGrade~eq~'6A+'~and~MaxGrade~eq~'6C'",
+		"Configuration~eq~'40° MoonBoard'~and~MinGrade~eq~'6C+'~and~MaxGrade~eq~'7B'",
+		"Configuration~eq~'40° MoonBoard'~and~MinGrade~eq~'7B+'~and~MaxGrade~eq~'8A'",
+		"Configuration~eq~'40° MoonBoard'~and~MinGrade~eq~'8A+'~and~MaxGrade~eq~'8B+'",
+	}
+
+	builders, err := SplitByGrade(query.New().Configuration(query.Forty), 4)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if len(builders) != len(expected) {
+		t.Errorf("Expected %d queries, got %d", len(expected), len(builders))
+		t.FailNow()
+	}
+
+	for i, builder := range builders {
+		q, errs := builder.Build()
+		if errs != nil {
+			t.Errorf("Unexpected error. %s", errs[0].Error())
+			t.FailNow()
+		}
+		if q.Filter() != expected[i] {
+			t.Errorf("Query Filter was incorrect, got %s, want: %s", q.Filter(), expected[i])
+		}
+	}
+}
+
+func TestSplitByGradeLimitsBucketsToGrades(t *testing.T) {
+	builders, err := SplitByGrade(query.New().MinGrade(query.SevenA).MaxGrade(query.SevenAPlus), 5)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if len(builders) != 2 {
+		t.Errorf("Expected 2 queries, got %d", len(builders))
+	}
+}
+
+func TestEstimateSplit(t *testing.T) {
+	counter := &fakeCounter{total: 30}
+	builders, _ := SplitByGrade(query.New(), 2)
+
+	plans, err := EstimateSplit(counter, builders, 15, 1)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if len(plans) != 2 || plans[1].Requests != 2 {
+		t.Errorf("Plans were incorrect, got %+v", plans)
+	}
+}
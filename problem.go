@@ -33,6 +33,13 @@ type HoldSetup struct {
 	AllowClimbMethods       bool        `json:"AllowClimbMethods"`
 }
 
+type Move struct {
+	ID          int    `json:"Id"`
+	Description string `json:"Description"`
+	IsStart     bool   `json:"IsStart"`
+	IsEnd       bool   `json:"IsEnd"`
+}
+
 type Problem struct {
 		Method                 string      `json:"Method"`
 		Name                   string      `json:"Name"`
@@ -48,12 +55,7 @@ type Problem struct {
 		Attempts      int  `json:"Attempts"`
 		Holdsetup     HoldSetup `json:"Holdsetup"`
 		IsBenchmark bool `json:"IsBenchmark"`
-		Moves       []struct {
-			ID          int    `json:"Id"`
-			Description string `json:"Description"`
-			IsStart     bool   `json:"IsStart"`
-			IsEnd       bool   `json:"IsEnd"`
-		} `json:"Moves"`
+		Moves       []Move `json:"Moves"`
 		Holdsets  interface{} `json:"Holdsets"`
 		Locations []struct {
 			ID              int         `json:"Id"`
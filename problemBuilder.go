@@ -0,0 +1,273 @@
+package moonapi
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cstdev/moonapi/query"
+)
+
+// Methods a problem can be climbed with.
+const (
+	FeetFollowHands   = "Feet follow hands"
+	ScrewOnsOnly      = "Screw ons only"
+	FootlessKickboard = "Footless + kickboard"
+)
+
+// The most start and finishing holds a problem can have.
+const (
+	maxStartHolds = 2
+	maxEndHolds   = 2
+)
+
+// Violation is returned by Build for each rule a new problem breaks.
+// Field names the builder method the value was set with.
+type Violation struct {
+	Field  string
+	Value  string
+	Reason string
+}
+
+func (v *Violation) Error() string {
+	return v.Reason
+}
+
+// ProblemBuilder assists with creating a new problem that follows the
+// rules of the board.
+type ProblemBuilder interface {
+	Grade(grade query.Grade) ProblemBuilder
+	Configuration(config query.Configuration) ProblemBuilder
+	Setup(setup query.Setup) ProblemBuilder
+	Method(method string) ProblemBuilder
+	HoldSets(holdSets ...query.HoldSet) ProblemBuilder
+	Start(positions ...string) ProblemBuilder
+	Move(positions ...string) ProblemBuilder
+	End(positions ...string) ProblemBuilder
+	HoldLayout(layout map[string]query.HoldSet) ProblemBuilder
+	Build() (Problem, []error)
+}
+
+type problemBuilder struct {
+	name       string
+	grade      query.Grade
+	gradeSet   bool
+	config     query.Configuration
+	setup      query.Setup
+	method     string
+	holdSets   []query.HoldSet
+	moves      []Move
+	holdLayout map[string]query.HoldSet
+}
+
+// NewProblem creates a ProblemBuilder for a problem with the given name,
+// climbed feet follow hands unless another Method is set.
+func NewProblem(name string) ProblemBuilder {
+	return &problemBuilder{name: name, method: FeetFollowHands}
+}
+
+// Grade sets the grade the setter gives the problem.
+func (pb *problemBuilder) Grade(grade query.Grade) ProblemBuilder {
+	pb.grade = grade
+	pb.gradeSet = true
+	return pb
+}
+
+// Configuration sets the angle of the board the problem is set on.
+func (pb *problemBuilder) Configuration(config query.Configuration) ProblemBuilder {
+	pb.config = config
+	return pb
+}
+
+// Setup sets the hold setup the problem is set on.
+func (pb *problemBuilder) Setup(setup query.Setup) ProblemBuilder {
+	pb.setup = setup
+	return pb
+}
+
+// Method sets how the problem is climbed, e.g. FeetFollowHands.
+func (pb *problemBuilder) Method(method string) ProblemBuilder {
+	pb.method = method
+	return pb
+}
+
+// HoldSets sets the hold sets the problem uses. If none are set, every
+// hold set on the setup can be used.
+func (pb *problemBuilder) HoldSets(holdSets ...query.HoldSet) ProblemBuilder {
+	pb.holdSets = append(pb.holdSets, holdSets...)
+	return pb
+}
+
+// Start adds start holds, given as board positions such as "F5".
+func (pb *problemBuilder) Start(positions ...string) ProblemBuilder {
+	return pb.addMoves(positions, true, false)
+}
+
+// Move adds holds between the start and finish.
+func (pb *problemBuilder) Move(positions ...string) ProblemBuilder {
+	return pb.addMoves(positions, false, false)
+}
+
+// End adds finishing holds.
+func (pb *problemBuilder) End(positions ...string) ProblemBuilder {
+	return pb.addMoves(positions, false, true)
+}
+
+// HoldLayout sets the hold set of the hold at each board position, so Build
+// can check every hold of the problem is on the board and in one of its
+// hold sets. Without it those checks are skipped.
+func (pb *problemBuilder) HoldLayout(layout map[string]query.HoldSet) ProblemBuilder {
+	pb.holdLayout = map[string]query.HoldSet{}
+	for position, holdSet := range layout {
+		pb.holdLayout[strings.ToUpper(position)] = holdSet
+	}
+	return pb
+}
+
+func (pb *problemBuilder) addMoves(positions []string, isStart bool, isEnd bool) ProblemBuilder {
+	for _, position := range positions {
+		description := strings.ToUpper(strings.TrimSpace(position))
+		pb.moves = append(pb.moves, Move{Description: description, IsStart: isStart, IsEnd: isEnd})
+	}
+	return pb
+}
+
+// Build checks the problem against the rules of the board and returns it
+// ready to be created, or every rule it breaks:
+// a name, grade, configuration and setup are required, the grade must be
+// within the range of the board, there must be 1 or 2 start and finishing
+// holds, finishing holds must be on the top row and no hold can be used
+// twice. Hold sets must be on the setup. Holds are only checked against
+// the hold sets when a HoldLayout has been given, as the position of each
+// hold set is not known otherwise.
+func (pb *problemBuilder) Build() (Problem, []error) {
+	var errs []error
+	violation := func(field string, value string, reason string) {
+		errs = append(errs, &Violation{Field: field, Value: value, Reason: reason})
+	}
+
+	if strings.TrimSpace(pb.name) == "" {
+		violation("Name", pb.name, "name is required")
+	}
+	if !pb.gradeSet {
+		violation("Grade", "", "grade is required")
+	}
+	if pb.config == "" {
+		violation("Configuration", "", "configuration is required")
+	}
+	if pb.setup == "" {
+		violation("Setup", "", "setup is required")
+	}
+
+	if pb.config != "" && pb.setup != "" {
+		layout, err := query.FindLayout(pb.setup, pb.config)
+		if err != nil {
+			violation("Configuration", string(pb.config), err.Error())
+		} else {
+			if pb.gradeSet && (pb.grade < layout.MinGrade || pb.grade > layout.MaxGrade) {
+				violation("Grade", pb.grade.String(), "grade "+pb.grade.String()+" is outside the range of "+
+					string(pb.setup)+" at "+string(pb.config)+" ("+layout.MinGrade.String()+" to "+layout.MaxGrade.String()+")")
+			}
+			for _, holdSet := range pb.holdSets {
+				if !layout.HasHoldSet(holdSet) {
+					violation("HoldSets", string(holdSet), string(holdSet)+" is not on "+string(pb.setup)+" at "+string(pb.config))
+				}
+			}
+		}
+	}
+
+	rows := query.RowsFor(pb.setup)
+
+	starts, ends := 0, 0
+	seen := map[string]bool{}
+	for _, move := range pb.moves {
+		field := moveField(move)
+		if move.IsStart {
+			starts++
+		}
+		if move.IsEnd {
+			ends++
+		}
+
+		position, err := query.ParsePositionOn(move.Description, rows)
+		if err != nil {
+			violation(field, move.Description, err.Error())
+			continue
+		}
+
+		if seen[move.Description] {
+			violation(field, move.Description, "hold "+move.Description+" is used more than once")
+		}
+		seen[move.Description] = true
+
+		if move.IsEnd && position.Row != rows {
+			violation(field, move.Description, "finishing hold "+move.Description+" must be on the top row, "+strconv.Itoa(rows))
+		}
+		pb.checkHoldLayout(move, violation)
+	}
+
+	if starts < 1 || starts > maxStartHolds {
+		violation("Start", strconv.Itoa(starts), "problems need 1 or 2 start holds, got "+strconv.Itoa(starts))
+	}
+	if ends < 1 || ends > maxEndHolds {
+		violation("End", strconv.Itoa(ends), "problems need 1 or 2 finishing holds, got "+strconv.Itoa(ends))
+	}
+
+	if len(errs) > 0 {
+		return Problem{}, errs
+	}
+	return pb.problem(), nil
+}
+
+// checkHoldLayout checks there is a hold at the move's position in one of
+// the problem's hold sets, when a hold layout has been given.
+func (pb *problemBuilder) checkHoldLayout(move Move, violation func(string, string, string)) {
+	if pb.holdLayout == nil {
+		return
+	}
+
+	holdSet, ok := pb.holdLayout[move.Description]
+	if !ok {
+		violation(moveField(move), move.Description, "there is no hold at "+move.Description)
+		return
+	}
+	if len(pb.holdSets) == 0 {
+		return
+	}
+	for _, set := range pb.holdSets {
+		if set == holdSet {
+			return
+		}
+	}
+	violation(moveField(move), move.Description, "hold "+move.Description+" is in "+string(holdSet)+" which the problem does not use")
+}
+
+func (pb *problemBuilder) problem() Problem {
+	problem := Problem{
+		Name:   strings.TrimSpace(pb.name),
+		Grade:  pb.grade.String(),
+		Method: pb.method,
+		Moves:  pb.moves,
+	}
+	problem.MoonBoardConfiguration.Description = string(pb.config)
+	problem.Holdsetup.Description = string(pb.setup)
+
+	if len(pb.holdSets) > 0 {
+		var holdSets []interface{}
+		for _, holdSet := range pb.holdSets {
+			holdSets = append(holdSets, string(holdSet))
+		}
+		problem.Holdsets = holdSets
+	}
+	return problem
+}
+
+// moveField returns the builder method a move was added with.
+func moveField(move Move) string {
+	switch {
+	case move.IsStart:
+		return "Start"
+	case move.IsEnd:
+		return "End"
+	}
+	return "Move"
+}
@@ -0,0 +1,147 @@
+package moonapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cstdev/moonapi/query"
+)
+
+func validProblem() ProblemBuilder {
+	return NewProblem("Test Problem").
+		Grade(query.SixBPlus).
+		Configuration(query.Forty).
+		Setup(query.Masters2017).
+		HoldSets(query.OS, query.A).
+		Start("f5").
+		Move("G8", "H11").
+		End("F18")
+}
+
+func violations(errs []error) map[string][]string {
+	out := map[string][]string{}
+	for _, err := range errs {
+		violation, ok := err.(*Violation)
+		if !ok {
+			continue
+		}
+		out[violation.Field] = append(out[violation.Field], violation.Reason)
+	}
+	return out
+}
+
+func TestNewProblemBuilds(t *testing.T) {
+	problem, errs := validProblem().Build()
+	if len(errs) > 0 {
+		t.Errorf("Unexpected errors. %v", errs)
+		t.FailNow()
+	}
+
+	if problem.Name != "Test Problem" || problem.Grade != "6B+" || problem.Method != FeetFollowHands {
+		t.Errorf("Unexpected problem, got %+v", problem)
+	}
+	if problem.MoonBoardConfiguration.Description != string(query.Forty) {
+		t.Errorf("Expected the configuration to be set, got %s", problem.MoonBoardConfiguration.Description)
+	}
+	if problem.Holdsetup.Description != string(query.Masters2017) {
+		t.Errorf("Expected the setup to be set, got %s", problem.Holdsetup.Description)
+	}
+	if len(problem.Moves) != 4 || problem.Moves[0] != (Move{Description: "F5", IsStart: true}) || !problem.Moves[3].IsEnd {
+		t.Errorf("Unexpected moves, got %+v", problem.Moves)
+	}
+	if names := problem.HoldSetNames(); len(names) != 2 {
+		t.Errorf("Expected 2 hold sets, got %v", names)
+	}
+}
+
+func TestNewProblemReportsEveryViolation(t *testing.T) {
+	_, errs := NewProblem(" ").Build()
+	got := violations(errs)
+
+	for _, field := range []string{"Name", "Grade", "Configuration", "Setup", "Start", "End"} {
+		if len(got[field]) != 1 {
+			t.Errorf("Expected a violation for %s, got %v", field, got)
+		}
+	}
+}
+
+func TestNewProblemHoldCounts(t *testing.T) {
+	_, errs := validProblem().Start("G2", "H3").End("E18", "K18").Build()
+	got := violations(errs)
+
+	if len(got["Start"]) != 1 || got["Start"][0] != "problems need 1 or 2 start holds, got 3" {
+		t.Errorf("Expected too many start holds, got %v", got)
+	}
+	if len(got["End"]) != 1 || got["End"][0] != "problems need 1 or 2 finishing holds, got 3" {
+		t.Errorf("Expected too many finishing holds, got %v", got)
+	}
+}
+
+func TestNewProblemFinishOnTopRow(t *testing.T) {
+	_, errs := NewProblem("Low Finish").Grade(query.SixBPlus).Configuration(query.Forty).Setup(query.Masters2017).
+		Start("F5").End("F17").Build()
+	got := violations(errs)
+
+	if len(got["End"]) != 1 || !strings.Contains(got["End"][0], "must be on the top row, 18") {
+		t.Errorf("Expected the finish to be on the top row, got %v", got)
+	}
+}
+
+func TestNewProblemDuplicatesAndInvalidHolds(t *testing.T) {
+	_, errs := validProblem().Move("G8", "Z3", "A19").Build()
+	got := violations(errs)
+
+	if len(got["Move"]) != 3 {
+		t.Errorf("Expected a duplicate and two invalid holds, got %v", got)
+	}
+}
+
+func TestNewProblemMiniBoard(t *testing.T) {
+	_, errs := NewProblem("Mini").Grade(query.SixA).Configuration(query.Forty).Setup(query.Mini2020).
+		HoldSets(query.D).Start("F5").End("F12").Build()
+	if len(errs) > 0 {
+		t.Errorf("Expected a finish on row 12 to be valid on the mini board, got %v", errs)
+	}
+
+	_, errs = NewProblem("Mini").Grade(query.SixA).Configuration(query.Forty).Setup(query.Mini2020).
+		Start("F5").End("F18").Build()
+	if len(violations(errs)["End"]) != 1 {
+		t.Errorf("Expected F18 to be off the mini board, got %v", errs)
+	}
+}
+
+func TestNewProblemGradeAndHoldSetsOnLayout(t *testing.T) {
+	_, errs := validProblem().Grade(query.FivePlus).HoldSets(query.D).Build()
+	got := violations(errs)
+
+	if len(got["Grade"]) != 1 || !strings.Contains(got["Grade"][0], "(6A+ to 8B+)") {
+		t.Errorf("Expected 5+ to be below the range of the Forty, got %v", got)
+	}
+	if len(got["HoldSets"]) != 1 {
+		t.Errorf("Expected hold set d to not be on the 2017 setup, got %v", got)
+	}
+
+	_, errs = validProblem().Setup(query.MoonBoard2016).Configuration(query.Twenty).Build()
+	if len(violations(errs)["Configuration"]) != 1 {
+		t.Errorf("Expected the 2016 setup not to be at 20 degrees, got %v", errs)
+	}
+}
+
+func TestNewProblemHoldLayout(t *testing.T) {
+	layout := map[string]query.HoldSet{"f5": query.OS, "G8": query.A, "H11": query.B, "F18": query.A}
+
+	_, errs := validProblem().HoldLayout(layout).Build()
+	got := violations(errs)
+
+	if len(got["Move"]) != 1 || !strings.Contains(got["Move"][0], "hold H11 is in hold set b") {
+		t.Errorf("Expected H11 to be in an unused hold set, got %v", got)
+	}
+
+	delete(layout, "G8")
+	_, errs = validProblem().HoldSets(query.B).HoldLayout(layout).Build()
+	got = violations(errs)
+
+	if len(got["Move"]) != 1 || got["Move"][0] != "there is no hold at G8" {
+		t.Errorf("Expected there to be no hold at G8, got %v", got)
+	}
+}
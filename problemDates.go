@@ -0,0 +1,60 @@
+package moonapi
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var dateFormat = regexp.MustCompile(`^/Date\((-?\d+)\)/$`)
+
+// ParseDate takes a date as returned by the website, e.g. /Date(1524237072990)/,
+// and returns it as a time. Empty or nil dates return the zero time.
+// errors if the value is not in the website's date format
+func ParseDate(value interface{}) (time.Time, error) {
+	if value == nil {
+		return time.Time{}, nil
+	}
+
+	date, ok := value.(string)
+	if !ok {
+		return time.Time{}, errors.New("date is not a string")
+	}
+	if date == "" {
+		return time.Time{}, nil
+	}
+
+	match := dateFormat.FindStringSubmatch(date)
+	if match == nil {
+		return time.Time{}, errors.New("invalid date: " + date)
+	}
+
+	millis, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, millis*int64(time.Millisecond)).UTC(), nil
+}
+
+// FormatDate returns a time in the format used by the website.
+func FormatDate(t time.Time) string {
+	return "/Date(" + strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10) + ")/"
+}
+
+// Inserted returns when the problem was added to the website.
+func (p Problem) Inserted() (time.Time, error) {
+	return ParseDate(p.DateInserted)
+}
+
+// Updated returns when the problem was last changed, or the zero time if
+// it has not been changed since it was added.
+func (p Problem) Updated() (time.Time, error) {
+	return ParseDate(p.DateUpdated)
+}
+
+// Deleted returns when the problem was removed from the website, or the
+// zero time if it has not been.
+func (p Problem) Deleted() (time.Time, error) {
+	return ParseDate(p.DateDeleted)
+}
@@ -0,0 +1,37 @@
+package moonapi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	expected := time.Date(2018, 4, 20, 15, 11, 12, 990000000, time.UTC)
+	date, err := ParseDate("/Date(1524237072990)/")
+	if err != nil {
+		t.Errorf("Unexpected error recieved: %v", err)
+		t.FailNow()
+	}
+
+	if !date.Equal(expected) {
+		t.Errorf("Date was incorrect. Got %s Expected %s", date, expected)
+	}
+
+	if FormatDate(date) != "/Date(1524237072990)/" {
+		t.Errorf("Formatted date was incorrect. Got %s", FormatDate(date))
+	}
+}
+
+func TestParseDateNilIsZero(t *testing.T) {
+	date, err := ParseDate(nil)
+	if err != nil || !date.IsZero() {
+		t.Errorf("Expected zero time, got %s %v", date, err)
+	}
+}
+
+func TestParseDateErrorsOnInvalidDate(t *testing.T) {
+	_, err := ParseDate("20 Apr 2018 16:11")
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+	}
+}
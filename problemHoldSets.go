@@ -0,0 +1,44 @@
+package moonapi
+
+import (
+	"sort"
+	"strings"
+)
+
+// HoldSetNames returns the lower case names of the hold sets a problem uses,
+// e.g. "hold set a", matching the values of query.HoldSet.
+// The names are read from the problem's Holdsets, or from its Locations if
+// those are missing. An empty slice is returned if neither are present.
+func (p Problem) HoldSetNames() []string {
+	names := map[string]bool{}
+	addHoldSetNames(names, p.Holdsets)
+	if len(names) == 0 {
+		for _, location := range p.Locations {
+			addHoldSetNames(names, location.Holdset)
+		}
+	}
+
+	var out []string
+	for name := range names {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// addHoldSetNames reads hold set names from the loosely typed values the
+// website returns: a name, an object with a Description or a list of either.
+func addHoldSetNames(names map[string]bool, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			names[strings.ToLower(v)] = true
+		}
+	case map[string]interface{}:
+		addHoldSetNames(names, v["Description"])
+	case []interface{}:
+		for _, item := range v {
+			addHoldSetNames(names, item)
+		}
+	}
+}
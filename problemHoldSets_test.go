@@ -0,0 +1,26 @@
+package moonapi
+
+import (
+	"testing"
+)
+
+func TestHoldSetNamesFromHoldsets(t *testing.T) {
+	problem := Problem{
+		Holdsets: []interface{}{
+			map[string]interface{}{"Description": "Hold Set B"},
+			map[string]interface{}{"Description": "Original School Holds"},
+		},
+	}
+
+	names := problem.HoldSetNames()
+	if len(names) != 2 || names[0] != "hold set b" || names[1] != "original school holds" {
+		t.Errorf("Hold set names were incorrect, got %v", names)
+	}
+}
+
+func TestHoldSetNamesFromEmptyProblem(t *testing.T) {
+	names := Problem{}.HoldSetNames()
+	if len(names) != 0 {
+		t.Errorf("Expected no hold set names, got %v", names)
+	}
+}
@@ -0,0 +1,184 @@
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// DefinitionVersion is the version of the Definition format written by this
+// package. Definitions with any other version are rejected when read.
+const DefinitionVersion = 1
+
+// Definition is the structured form of a query. It holds the values set on
+// a QueryBuilder rather than the rendered filter and sort strings so it can
+// be stored as JSON or YAML, shared, and turned back into a builder.
+// MinGrade and MaxGrade are only present if they were set explicitly.
+type Definition struct {
+	Version        int             `json:"version" yaml:"version"`
+	Term           string          `json:"term,omitempty" yaml:"term,omitempty"`
+	Sort           []SortKey       `json:"sort,omitempty" yaml:"sort,omitempty"`
+	Setup          Setup           `json:"setup,omitempty" yaml:"setup,omitempty"`
+	Configurations []Configuration `json:"configurations,omitempty" yaml:"configurations,omitempty"`
+	HoldSets       []HoldSet       `json:"holdSets,omitempty" yaml:"holdSets,omitempty"`
+	Filters        []Filter        `json:"filters,omitempty" yaml:"filters,omitempty"`
+	MinGrade       *Grade          `json:"minGrade,omitempty" yaml:"minGrade,omitempty"`
+	MaxGrade       *Grade          `json:"maxGrade,omitempty" yaml:"maxGrade,omitempty"`
+	Page           int             `json:"page,omitempty" yaml:"page,omitempty"`
+	PageSize       int             `json:"pageSize,omitempty" yaml:"pageSize,omitempty"`
+}
+
+// SavedSearch is a named Definition, used to keep searches in config files
+// and share them between people.
+type SavedSearch struct {
+	Name        string     `json:"name" yaml:"name"`
+	Description string     `json:"description,omitempty" yaml:"description,omitempty"`
+	Query       Definition `json:"query" yaml:"query"`
+}
+
+// definition has the same fields as Definition without its marshalling
+// methods, so they can call the default encoders without recursing.
+type definition Definition
+
+// MarshalJSON writes the Definition as JSON, stamped with the current version.
+func (d Definition) MarshalJSON() ([]byte, error) {
+	d.Version = DefinitionVersion
+	return json.Marshal(definition(d))
+}
+
+// UnmarshalJSON reads a Definition from JSON
+// errors if the version is not supported or any value is unknown
+func (d *Definition) UnmarshalJSON(data []byte) error {
+	var out definition
+	if err := json.Unmarshal(data, &out); err != nil {
+		return err
+	}
+	return d.set(Definition(out))
+}
+
+// MarshalYAML writes the Definition as YAML, stamped with the current version.
+func (d Definition) MarshalYAML() (interface{}, error) {
+	d.Version = DefinitionVersion
+	return definition(d), nil
+}
+
+// UnmarshalYAML reads a Definition from YAML
+// errors if the version is not supported or any value is unknown
+func (d *Definition) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var out definition
+	if err := unmarshal(&out); err != nil {
+		return err
+	}
+	return d.set(Definition(out))
+}
+
+func (d *Definition) set(in Definition) error {
+	if err := in.validate(); err != nil {
+		return err
+	}
+	*d = in
+	return nil
+}
+
+func (d Definition) validate() error {
+	if d.Version != DefinitionVersion {
+		return errors.New("unsupported query definition version " + strconv.Itoa(d.Version))
+	}
+
+	var invalid []string
+	for _, key := range d.Sort {
+		if !isOrder(key.Order) {
+			invalid = append(invalid, "sort order '"+string(key.Order)+"'")
+		}
+	}
+	if d.Setup != "" && len(LayoutsFor(d.Setup)) == 0 {
+		invalid = append(invalid, "setup '"+string(d.Setup)+"'")
+	}
+	for _, config := range d.Configurations {
+		if _, ok := configurationGrades[config]; !ok {
+			invalid = append(invalid, "configuration '"+string(config)+"'")
+		}
+	}
+	for _, holdSet := range d.HoldSets {
+		if !isHoldSet(holdSet) {
+			invalid = append(invalid, "hold set '"+string(holdSet)+"'")
+		}
+	}
+	for _, filter := range d.Filters {
+		if !isFilter(filter) {
+			invalid = append(invalid, "filter '"+string(filter)+"'")
+		}
+	}
+
+	if len(invalid) > 0 {
+		return errors.New("unknown " + strings.Join(invalid, ", ") + " in query definition")
+	}
+	return nil
+}
+
+// Definer is implemented by queries and builders that can describe the
+// values they were built from, as those created by this package can.
+type Definer interface {
+	Definition() Definition
+}
+
+// DefinitionOf returns the Definition a Query was built from
+// errors if the Query was not built by a QueryBuilder from this package
+func DefinitionOf(q Query) (Definition, error) {
+	definer, ok := q.(Definer)
+	if !ok {
+		return Definition{}, errors.New("query does not have a definition")
+	}
+	return definer.Definition(), nil
+}
+
+// FromDefinition creates a QueryBuilder with every value from the Definition
+// set on it, as if each builder method had been called in turn.
+func FromDefinition(d Definition) QueryBuilder {
+	builder := New()
+	if d.Term != "" {
+		builder.Term(d.Term)
+	}
+	for _, key := range d.Sort {
+		builder.Sort(key.Order, key.Asc)
+	}
+	if d.Setup != "" {
+		builder.Setup(d.Setup)
+	}
+	for _, config := range d.Configurations {
+		builder.Configuration(config)
+	}
+	for _, holdSet := range d.HoldSets {
+		builder.HoldSet(holdSet)
+	}
+	for _, filter := range d.Filters {
+		builder.Filter(filter)
+	}
+	if d.MinGrade != nil {
+		builder.MinGrade(*d.MinGrade)
+	}
+	if d.MaxGrade != nil {
+		builder.MaxGrade(*d.MaxGrade)
+	}
+	if d.Page != 0 {
+		builder.Page(d.Page)
+	}
+	if d.PageSize != 0 {
+		builder.PageSize(d.PageSize)
+	}
+	return builder
+}
+
+// Grades returns the range of grades the Definition searches between,
+// using the defaults for its setup and configurations where no grade was set.
+func (d Definition) Grades() GradeRange {
+	bounds, _ := gradeBounds(d.Setup, d.Configurations)
+	if d.MinGrade != nil {
+		bounds.Min = *d.MinGrade
+	}
+	if d.MaxGrade != nil {
+		bounds.Max = *d.MaxGrade
+	}
+	return bounds
+}
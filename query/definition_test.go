@@ -0,0 +1,188 @@
+package query
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestBuilderMarshalsToJSONDefinition(t *testing.T) {
+	expected := `{"version":1,"term":"a climb","sort":[{"order":"Grade","asc":true}],"configurations":["40° MoonBoard"],"holdSets":["hold set a"],"filters":["Benchmarks"],"minGrade":"7A","page":2,"pageSize":15}`
+	builder := New().Term("a climb").Sort(Difficulty, true).Configuration(Forty).HoldSet(A).Filter(Benchmarks).MinGrade(SevenA).Page(2)
+
+	out, err := json.Marshal(builder)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if string(out) != expected {
+		t.Errorf("JSON was incorrect, got %s, want: %s", out, expected)
+	}
+}
+
+func TestQueryMarshalsToJSONDefinition(t *testing.T) {
+	expected := `{"version":1,"setup":"MoonBoard Masters 2017","maxGrade":"7B+","page":1,"pageSize":15}`
+	query, _ := New().Setup(Masters2017).MaxGrade(SevenBPlus).Build()
+
+	out, err := json.Marshal(query)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if string(out) != expected {
+		t.Errorf("JSON was incorrect, got %s, want: %s", out, expected)
+	}
+}
+
+func TestBuilderUnmarshalsFromJSON(t *testing.T) {
+	data := `{"version":1,"term":"a climb","sort":[{"order":"Rating","asc":false}],"configurations":["40° MoonBoard"],"minGrade":"7a+"}`
+	expectedFilter := "Configuration~eq~'40° MoonBoard'~and~Name~contains~'a climb'~and~MinGrade~eq~'7A+'~and~MaxGrade~eq~'8B+'"
+	expectedSort := "Rating-desc"
+
+	builder := New()
+	err := json.Unmarshal([]byte(data), builder)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	query, errs := builder.Build()
+	if errs != nil {
+		t.Errorf("Unexpected error. %s", errs[0].Error())
+		t.FailNow()
+	}
+
+	if query.Filter() != expectedFilter {
+		t.Errorf("Query Filter was incorrect, got %s, want: %s", query.Filter(), expectedFilter)
+	}
+
+	if query.Sort() != expectedSort {
+		t.Errorf("Query sort was incorrect, got %s, want: %s", query.Sort(), expectedSort)
+	}
+}
+
+func TestDefinitionRoundTripsThroughYAML(t *testing.T) {
+	builder := New().Term("a climb").Sort(Newest, false).Sort(Name, true).Setup(Masters2019).HoldSet(WoodB).MaxGrade(SevenC)
+	expected, _ := builder.Build()
+
+	out, err := yaml.Marshal(builder)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	var saved Definition
+	err = yaml.Unmarshal(out, &saved)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	query, _ := FromDefinition(saved).Build()
+	if query.Filter() != expected.Filter() || query.Sort() != expected.Sort() {
+		t.Errorf("Query was incorrect, got %s %s, want: %s %s", query.Filter(), query.Sort(), expected.Filter(), expected.Sort())
+	}
+}
+
+func TestSavedSearchReadsFromYAML(t *testing.T) {
+	data := `
+name: Benchmarks in the 7s
+query:
+  version: 1
+  configurations: ["40° MoonBoard"]
+  filters: [Benchmarks]
+  minGrade: 7A
+  maxGrade: 7C+
+`
+	expected := "Configuration~eq~'40° MoonBoard'~and~Benchmarks~eq~''~and~MinGrade~eq~'7A'~and~MaxGrade~eq~'7C+'"
+
+	var saved SavedSearch
+	err := yaml.Unmarshal([]byte(data), &saved)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if saved.Name != "Benchmarks in the 7s" {
+		t.Errorf("Name was incorrect, got %s", saved.Name)
+	}
+
+	query, _ := FromDefinition(saved.Query).Build()
+	if query.Filter() != expected {
+		t.Errorf("Query Filter was incorrect, got %s, want: %s", query.Filter(), expected)
+	}
+}
+
+func TestDefinitionErrorsOnUnsupportedVersion(t *testing.T) {
+	expectedError := "unsupported query definition version 2"
+	var d Definition
+	err := json.Unmarshal([]byte(`{"version":2}`), &d)
+
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+		t.FailNow()
+	}
+
+	if err.Error() != expectedError {
+		t.Errorf("Incorrect error provided. Got: %s Expected: %s", err.Error(), expectedError)
+	}
+}
+
+func TestDefinitionErrorsOnUnknownValues(t *testing.T) {
+	expectedError := "unknown hold set 'hold set z', filter 'Favourites' in query definition"
+	var d Definition
+	err := json.Unmarshal([]byte(`{"version":1,"holdSets":["hold set z"],"filters":["Favourites"]}`), &d)
+
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+		t.FailNow()
+	}
+
+	if err.Error() != expectedError {
+		t.Errorf("Incorrect error provided. Got: %s Expected: %s", err.Error(), expectedError)
+	}
+}
+
+func TestDefinitionErrorsOnUnknownGrade(t *testing.T) {
+	var d Definition
+	err := json.Unmarshal([]byte(`{"version":1,"minGrade":"9A"}`), &d)
+
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+	}
+}
+
+// rawQuery is a Query created outside of this package.
+type rawQuery struct{}
+
+func (rawQuery) Sort() string   { return "" }
+func (rawQuery) Filter() string { return "" }
+func (rawQuery) Page() int      { return 1 }
+func (rawQuery) PageSize() int  { return 15 }
+
+func TestDefinitionOfReturnsBuiltDefinition(t *testing.T) {
+	query, errs := New().Term("a climb").HoldSet(A).Build()
+	if len(errs) > 0 {
+		t.Errorf("Unexpected error. %s", errs[0].Error())
+		t.FailNow()
+	}
+
+	d, err := DefinitionOf(query)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+	if d.Term != "a climb" || len(d.HoldSets) != 1 || d.HoldSets[0] != A {
+		t.Errorf("Incorrect definition returned, got %+v", d)
+	}
+}
+
+func TestDefinitionOfErrorsOnOtherQueries(t *testing.T) {
+	_, err := DefinitionOf(rawQuery{})
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+	}
+}
@@ -0,0 +1,293 @@
+package query
+
+import (
+	"errors"
+	"strings"
+)
+
+// Setup specifies the set of holds mounted on a board, named the same way
+// the website describes a problem's Holdsetup.
+type Setup string
+
+const (
+	MoonBoard2016 Setup = "MoonBoard 2016"
+	Masters2017   Setup = "MoonBoard Masters 2017"
+	Masters2019   Setup = "MoonBoard Masters 2019"
+	Masters2020   Setup = "MoonBoard 2020"
+	Mini2020      Setup = "Mini MoonBoard 2020"
+)
+
+// Layout describes a physical board: the hold setup on it, the angle it is
+// set at, the hold sets that can be mounted and the grades problems on it
+// range between.
+type Layout struct {
+	Setup         Setup
+	Year          int
+	Configuration Configuration
+	HoldSets      []HoldSet
+	MinGrade      Grade
+	MaxGrade      Grade
+}
+
+// GradeRange is the lowest and highest grade problems on a board are set at.
+type GradeRange struct {
+	Min Grade
+	Max Grade
+}
+
+// configurationGrades holds the grades each board angle supports, used when
+// no hold setup has been chosen.
+var configurationGrades = map[Configuration]GradeRange{
+	Forty:  {Min: SixAPlus, Max: EightBPlus},
+	Twenty: {Min: FivePlus, Max: EightBPlus},
+}
+
+var layouts = []Layout{
+	{
+		Setup:         MoonBoard2016,
+		Year:          2016,
+		Configuration: Forty,
+		HoldSets:      []HoldSet{OS, A, B},
+		MinGrade:      SixAPlus,
+		MaxGrade:      EightBPlus,
+	},
+	{
+		Setup:         Masters2017,
+		Year:          2017,
+		Configuration: Forty,
+		HoldSets:      []HoldSet{OS, Wood, A, B, C},
+		MinGrade:      SixAPlus,
+		MaxGrade:      EightBPlus,
+	},
+	{
+		Setup:         Masters2017,
+		Year:          2017,
+		Configuration: Twenty,
+		HoldSets:      []HoldSet{OS, Wood, A, B, C},
+		MinGrade:      FivePlus,
+		MaxGrade:      EightBPlus,
+	},
+	{
+		Setup:         Masters2019,
+		Year:          2019,
+		Configuration: Forty,
+		HoldSets:      []HoldSet{OS, Wood, WoodB, WoodC, A, B},
+		MinGrade:      SixAPlus,
+		MaxGrade:      EightBPlus,
+	},
+	{
+		Setup:         Masters2019,
+		Year:          2019,
+		Configuration: Twenty,
+		HoldSets:      []HoldSet{OS, Wood, WoodB, WoodC, A, B},
+		MinGrade:      FivePlus,
+		MaxGrade:      EightBPlus,
+	},
+	{
+		Setup:         Masters2020,
+		Year:          2020,
+		Configuration: Forty,
+		HoldSets:      []HoldSet{OS, Wood, WoodB, WoodC, A, B},
+		MinGrade:      SixAPlus,
+		MaxGrade:      EightBPlus,
+	},
+	{
+		Setup:         Mini2020,
+		Year:          2020,
+		Configuration: Forty,
+		HoldSets:      []HoldSet{D, E},
+		MinGrade:      FivePlus,
+		MaxGrade:      EightAPlus,
+	},
+}
+
+// Layouts returns every board layout known to the website.
+func Layouts() []Layout {
+	out := make([]Layout, len(layouts))
+	copy(out, layouts)
+	return out
+}
+
+// LayoutsFor returns the layouts available for a hold setup, one for each
+// angle the setup can be used at.
+func LayoutsFor(setup Setup) []Layout {
+	var out []Layout
+	for _, layout := range layouts {
+		if layout.Setup == setup {
+			out = append(out, layout)
+		}
+	}
+	return out
+}
+
+// FindLayout returns the layout for a hold setup at the given configuration
+// errors if the setup cannot be used at that angle
+func FindLayout(setup Setup, config Configuration) (*Layout, error) {
+	for _, layout := range layouts {
+		if layout.Setup == setup && layout.Configuration == config {
+			found := layout
+			return &found, nil
+		}
+	}
+	return nil, errors.New(string(setup) + " is not available at " + string(config))
+}
+
+// ConfigurationGrades returns the range of grades problems can be set at
+// on a configuration
+// errors if the configuration is not known
+func ConfigurationGrades(config Configuration) (*GradeRange, error) {
+	grades, ok := configurationGrades[config]
+	if !ok {
+		return nil, errors.New("no grades known for configuration " + string(config))
+	}
+	return &grades, nil
+}
+
+// HasHoldSet reports whether the hold set can be mounted on the layout.
+func (l Layout) HasHoldSet(holdSet HoldSet) bool {
+	for _, set := range l.HoldSets {
+		if set == holdSet {
+			return true
+		}
+	}
+	return false
+}
+
+// candidateLayouts returns the layouts a query could be run against given
+// the selected setup and configurations. An empty setup or configuration
+// list matches every layout. A BuildError is returned for each configuration
+// that isn't known or, when a setup is selected, isn't available for it.
+func candidateLayouts(setup Setup, configs []Configuration) ([]Layout, []error) {
+	var errs []error
+	var out []Layout
+
+	if len(configs) == 0 {
+		for _, layout := range layouts {
+			if setup == "" || layout.Setup == setup {
+				out = append(out, layout)
+			}
+		}
+		return out, nil
+	}
+
+	for _, config := range configs {
+		if setup != "" {
+			layout, err := FindLayout(setup, config)
+			if err != nil {
+				errs = append(errs, &BuildError{Field: "Configuration", Value: string(config), Reason: err.Error()})
+				continue
+			}
+			out = append(out, *layout)
+			continue
+		}
+		if _, err := ConfigurationGrades(config); err != nil {
+			errs = append(errs, &BuildError{Field: "Configuration", Value: string(config), Reason: "unknown configuration " + string(config)})
+			continue
+		}
+		for _, layout := range layouts {
+			if layout.Configuration == config {
+				out = append(out, layout)
+			}
+		}
+	}
+	return out, errs
+}
+
+// gradeBounds returns the widest range of grades supported across the
+// selected setup and configurations. Layout grades are used when a setup is
+// chosen, otherwise the configuration table is used.
+func gradeBounds(setup Setup, configs []Configuration) (GradeRange, error) {
+	bounds := GradeRange{Min: FivePlus, Max: EightBPlus}
+
+	var ranges []GradeRange
+	if setup != "" {
+		candidates, errs := candidateLayouts(setup, configs)
+		if len(errs) > 0 {
+			return bounds, errs[0]
+		}
+		for _, layout := range candidates {
+			ranges = append(ranges, GradeRange{Min: layout.MinGrade, Max: layout.MaxGrade})
+		}
+	} else {
+		for _, config := range configs {
+			grades, err := ConfigurationGrades(config)
+			if err != nil {
+				return bounds, err
+			}
+			ranges = append(ranges, *grades)
+		}
+	}
+
+	if len(ranges) == 0 {
+		return bounds, nil
+	}
+
+	bounds = ranges[0]
+	for _, r := range ranges[1:] {
+		if r.Min < bounds.Min {
+			bounds.Min = r.Min
+		}
+		if r.Max > bounds.Max {
+			bounds.Max = r.Max
+		}
+	}
+	return bounds, nil
+}
+
+// validateLayout checks that every hold set requested can be found on at
+// least one of the layouts the query could be run against.
+func validateLayout(setup Setup, configs []Configuration, holdSets []HoldSet) []error {
+	candidates, errs := candidateLayouts(setup, configs)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	for _, holdSet := range holdSets {
+		found := false
+		for _, layout := range candidates {
+			if layout.HasHoldSet(holdSet) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, &BuildError{Field: "HoldSet", Value: string(holdSet), Reason: string(holdSet) + " is not available on " + describeLayouts(setup, configs)})
+		}
+	}
+	return errs
+}
+
+func describeLayouts(setup Setup, configs []Configuration) string {
+	var parts []string
+	if setup != "" {
+		parts = append(parts, string(setup))
+	}
+	for _, config := range configs {
+		parts = append(parts, string(config))
+	}
+	if len(parts) == 0 {
+		return "any board"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ToSetup takes a string and returns its corresponding Setup value
+// errors if the string passed is not valid
+func ToSetup(setup string) (*Setup, error) {
+	var setupType Setup
+	switch strings.ToLower(setup) {
+	case "2016":
+		setupType = MoonBoard2016
+	case "2017", "masters2017":
+		setupType = Masters2017
+	case "2019", "masters2019":
+		setupType = Masters2019
+	case "2020", "masters2020":
+		setupType = Masters2020
+	case "mini", "mini2020":
+		setupType = Mini2020
+	default:
+		return nil, errors.New("String passed to ToSetup was not a valid Setup")
+	}
+	return &setupType, nil
+}
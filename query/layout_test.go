@@ -0,0 +1,163 @@
+package query
+
+import (
+	"testing"
+)
+
+func TestFindLayoutReturnsLayout(t *testing.T) {
+	layout, err := FindLayout(Masters2019, Twenty)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if layout.Year != 2019 || layout.MinGrade != FivePlus {
+		t.Errorf("Incorrect layout returned, got %+v", layout)
+	}
+
+	if !layout.HasHoldSet(WoodC) {
+		t.Errorf("Expected %s to be available on %s", WoodC, layout.Setup)
+	}
+}
+
+func TestFindLayoutErrorsOnUnavailableAngle(t *testing.T) {
+	expectedError := "MoonBoard 2016 is not available at 25° MoonBoard"
+	_, err := FindLayout(MoonBoard2016, Twenty)
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+		t.FailNow()
+	}
+
+	if err.Error() != expectedError {
+		t.Errorf("Incorrect error provided. Got: %s Expected: %s", err.Error(), expectedError)
+	}
+}
+
+func TestFindLayoutReturnsMasters2020(t *testing.T) {
+	setup, err := ToSetup("2020")
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	layout, err := FindLayout(*setup, Forty)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if layout.Setup != Masters2020 || layout.Year != 2020 {
+		t.Errorf("Incorrect layout returned, got %+v", layout)
+	}
+}
+
+func TestLayoutsForSetup(t *testing.T) {
+	expected := 2
+	layouts := LayoutsFor(Masters2017)
+	if len(layouts) != expected {
+		t.Errorf("Incorrect number of layouts, got %d, want: %d", len(layouts), expected)
+	}
+}
+
+func TestBuilderSetupIsNotAddedToFilter(t *testing.T) {
+	expected := "Configuration~eq~'40° MoonBoard'~and~Holdsets~eq~'wooden holds b'~and~MinGrade~eq~'6A+'~and~MaxGrade~eq~'8B+'"
+	builder := New()
+	query, err := builder.Setup(Masters2019).Configuration(Forty).HoldSet(WoodB).Build()
+
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err[0].Error())
+		t.FailNow()
+	}
+
+	if query.Filter() != expected {
+		t.Errorf("Query Filter was incorrect, got %s, want: %s", query.Filter(), expected)
+	}
+}
+
+func TestBuilderHoldSetNotOnSetupErrors(t *testing.T) {
+	expectedError := "hold set c is not available on MoonBoard Masters 2019"
+	builder := New()
+	_, err := builder.Setup(Masters2019).HoldSet(C).Build()
+
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+		t.FailNow()
+	}
+
+	if err[0].Error() != expectedError {
+		t.Errorf("Incorrect error provided. Got: %s Expected: %s", err[0].Error(), expectedError)
+	}
+}
+
+func TestBuilderHoldSetNotOnConfigurationErrors(t *testing.T) {
+	expectedError := "hold set d is not available on 25° MoonBoard"
+	builder := New()
+	_, err := builder.Configuration(Twenty).HoldSet(D).Build()
+
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+		t.FailNow()
+	}
+
+	if err[0].Error() != expectedError {
+		t.Errorf("Incorrect error provided. Got: %s Expected: %s", err[0].Error(), expectedError)
+	}
+}
+
+func TestBuilderSetupNotAtConfigurationErrors(t *testing.T) {
+	expectedError := "Mini MoonBoard 2020 is not available at 25° MoonBoard"
+	builder := New()
+	_, err := builder.Setup(Mini2020).Configuration(Twenty).Build()
+
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+		t.FailNow()
+	}
+
+	if err[0].Error() != expectedError {
+		t.Errorf("Incorrect error provided. Got: %s Expected: %s", err[0].Error(), expectedError)
+	}
+}
+
+func TestBuilderUnknownConfigurationErrors(t *testing.T) {
+	expectedError := "unknown configuration 30° MoonBoard"
+	builder := New()
+	_, err := builder.Configuration(Configuration("30° MoonBoard")).Build()
+
+	if len(err) != 1 {
+		t.Errorf("Expected a single error, got %v", err)
+		t.FailNow()
+	}
+
+	buildErr, ok := err[0].(*BuildError)
+	if !ok || buildErr.Field != "Configuration" || buildErr.Value != "30° MoonBoard" {
+		t.Errorf("Expected a Configuration BuildError, got %#v", err[0])
+	}
+	if err[0].Error() != expectedError {
+		t.Errorf("Incorrect error provided. Got: %s Expected: %s", err[0].Error(), expectedError)
+	}
+}
+
+func TestToSetupErrorsOnInvalidValue(t *testing.T) {
+	_, err := ToSetup("Test")
+	if err == nil {
+		t.Error("Expected error not recieved")
+	}
+}
+
+func TestConfigurationGrades(t *testing.T) {
+	grades, err := ConfigurationGrades(Forty)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if grades.Min != SixAPlus || grades.Max != EightBPlus {
+		t.Errorf("Incorrect grades returned, got %+v", grades)
+	}
+
+	_, err = ConfigurationGrades(Configuration("Test"))
+	if err == nil {
+		t.Error("Expected error not recieved")
+	}
+}
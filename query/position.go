@@ -0,0 +1,65 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The size of a full MoonBoard, columns A to K and rows 1 to 18, and the
+// number of rows on a Mini MoonBoard.
+const (
+	Columns  = 11
+	Rows     = 18
+	MiniRows = 12
+)
+
+// RowsFor returns the number of rows on the board for a hold setup.
+func RowsFor(setup Setup) int {
+	if setup == Mini2020 {
+		return MiniRows
+	}
+	return Rows
+}
+
+// Position is a hold on the board. Column is 0 for A up to 10 for K and
+// Row is 1 at the bottom of the board up to 18 at the top.
+type Position struct {
+	Column int
+	Row    int
+}
+
+// ParsePosition parses a board position such as "F5" or "k18" on a full
+// size board.
+// errors if the column or row is not on the board
+func ParsePosition(s string) (Position, error) {
+	return ParsePositionOn(s, Rows)
+}
+
+// ParsePositionOn parses a board position on a board with the given number
+// of rows, see RowsFor.
+// errors if the column or row is not on the board
+func ParsePositionOn(s string, rows int) (Position, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if len(s) < 2 {
+		return Position{}, errors.New("invalid hold position '" + s + "'")
+	}
+
+	column := int(s[0]) - 'A'
+	if column < 0 || column >= Columns {
+		return Position{}, fmt.Errorf("invalid hold position '%s', column must be A to %c", s, 'A'+Columns-1)
+	}
+
+	row, err := strconv.Atoi(s[1:])
+	if err != nil || row < 1 || row > rows {
+		return Position{}, fmt.Errorf("invalid hold position '%s', row must be 1 to %d", s, rows)
+	}
+
+	return Position{Column: column, Row: row}, nil
+}
+
+// String returns the position as it is written on the board, e.g. F5.
+func (p Position) String() string {
+	return string(rune('A'+p.Column)) + strconv.Itoa(p.Row)
+}
@@ -0,0 +1,51 @@
+package query
+
+import (
+	"testing"
+)
+
+func TestParsePosition(t *testing.T) {
+	position, err := ParsePosition(" k18")
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if position.Column != 10 || position.Row != 18 {
+		t.Errorf("Expected column 10 row 18, got %+v", position)
+	}
+
+	if position.String() != "K18" {
+		t.Errorf("Expected K18, got %s", position.String())
+	}
+}
+
+func TestParsePositionOffBoard(t *testing.T) {
+	for _, s := range []string{"", "F", "L5", "F0", "F19", "5F"} {
+		if _, err := ParsePosition(s); err == nil {
+			t.Errorf("Expected an error parsing '%s'", s)
+		}
+	}
+}
+
+func TestParsePositionOnMiniBoard(t *testing.T) {
+	if _, err := ParsePositionOn("F12", MiniRows); err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+	}
+
+	expectedError := "invalid hold position 'F13', row must be 1 to 12"
+	_, err := ParsePositionOn("F13", RowsFor(Mini2020))
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+		t.FailNow()
+	}
+	if err.Error() != expectedError {
+		t.Errorf("Incorrect error provided. Got: %s Expected: %s", err.Error(), expectedError)
+	}
+}
+
+func TestRowsFor(t *testing.T) {
+	if RowsFor(Mini2020) != MiniRows || RowsFor(Masters2019) != Rows || RowsFor("") != Rows {
+		t.Errorf("Expected 12 rows on the mini board and 18 otherwise")
+	}
+}
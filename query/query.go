@@ -2,9 +2,10 @@ package query
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
-	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -27,23 +28,45 @@ const descString = "-desc"
 const ascString = "-asc"
 
 const (
-	Newest     Order = "New"
-	Difficulty Order = "Grade"
-	Rating     Order = "Rating"
-	Repeats    Order = "Repeats"
+	Newest       Order = "New"
+	Difficulty   Order = "Grade"
+	Rating       Order = "Rating"
+	Repeats      Order = "Repeats"
+	Name         Order = "Name"
+	SetterName   Order = "Setter"
+	DateInserted Order = "DateInserted"
+	UserRating   Order = "UserRating"
 )
 
+// SortKey is a single Order and the direction to sort it in.
+type SortKey struct {
+	Order Order `json:"order" yaml:"order"`
+	Asc   bool  `json:"asc" yaml:"asc"`
+}
+
+// String returns the key in the form the website expects, e.g. Grade-asc
+func (k SortKey) String() string {
+	if k.Asc {
+		return string(k.Order) + ascString
+	}
+	return string(k.Order) + descString
+}
+
 const (
 	Forty  Configuration = "40° MoonBoard"
 	Twenty Configuration = "25° MoonBoard"
 )
 
 const (
-	OS   HoldSet = "original school holds"
-	Wood HoldSet = "wooden holds"
-	A    HoldSet = "hold set a"
-	B    HoldSet = "hold set b"
-	C    HoldSet = "hold set c"
+	OS    HoldSet = "original school holds"
+	Wood  HoldSet = "wooden holds"
+	WoodB HoldSet = "wooden holds b"
+	WoodC HoldSet = "wooden holds c"
+	A     HoldSet = "hold set a"
+	B     HoldSet = "hold set b"
+	C     HoldSet = "hold set c"
+	D     HoldSet = "hold set d"
+	E     HoldSet = "hold set e"
 )
 
 const (
@@ -74,6 +97,45 @@ const (
 
 var gradeStrings = [...]string{"5+", "6A", "6A+", "6B", "6B+", "6C", "6C+", "7A", "7A+", "7B", "7B+", "7C", "7C+", "8A", "8A+", "8B", "8B+"}
 
+// String returns the grade as it is written on the website, e.g. 7A+
+func (g Grade) String() string {
+	if g < FivePlus || int(g) >= len(gradeStrings) {
+		return "Grade(" + strconv.Itoa(int(g)) + ")"
+	}
+	return gradeStrings[g]
+}
+
+// MarshalText writes the grade as it is written on the website.
+func (g Grade) MarshalText() ([]byte, error) {
+	if g < FivePlus || int(g) >= len(gradeStrings) {
+		return nil, errors.New("cannot marshal unknown grade " + strconv.Itoa(int(g)))
+	}
+	return []byte(gradeStrings[g]), nil
+}
+
+// UnmarshalText reads a grade written as it is on the website.
+func (g *Grade) UnmarshalText(text []byte) error {
+	for i, grade := range gradeStrings {
+		if strings.EqualFold(grade, string(text)) {
+			*g = Grade(i)
+			return nil
+		}
+	}
+	return errors.New("unknown grade '" + string(text) + "'")
+}
+
+// BuildError is returned by Build for each invalid value set on a
+// QueryBuilder. Field names the builder method the value was set with.
+type BuildError struct {
+	Field  string
+	Value  string
+	Reason string
+}
+
+func (e *BuildError) Error() string {
+	return e.Reason
+}
+
 // Query contains the required strings to pass to the website in a request
 type Query interface {
 	Sort() string
@@ -83,11 +145,11 @@ type Query interface {
 }
 
 type query struct {
-	term     string
-	sort     string
-	filter   string
-	page     int
-	pageSize int
+	sort       string
+	filter     string
+	page       int
+	pageSize   int
+	definition Definition
 }
 
 func (q *query) Filter() string {
@@ -106,10 +168,26 @@ func (q *query) PageSize() int {
 	return q.pageSize
 }
 
+// Definition returns the structured values the query was built from.
+func (q *query) Definition() Definition {
+	return q.definition
+}
+
+// MarshalJSON writes the query as its Definition.
+func (q *query) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.definition)
+}
+
+// MarshalYAML writes the query as its Definition.
+func (q *query) MarshalYAML() (interface{}, error) {
+	return q.definition, nil
+}
+
 // QueryBuilder assists with the building of a Query
 type QueryBuilder interface {
 	Term(searchTerm string) QueryBuilder
 	Sort(order Order, asc bool) QueryBuilder
+	Setup(setup Setup) QueryBuilder
 	Configuration(filter Configuration) QueryBuilder
 	HoldSet(filter HoldSet) QueryBuilder
 	Filter(filter Filter) QueryBuilder
@@ -121,16 +199,19 @@ type QueryBuilder interface {
 }
 
 type queryBuilder struct {
-	term          string
-	order         string
-	configuration string
-	holdSet       string
-	filter        string
-	minGrade      Grade
-	maxGrade      Grade
-	page          int
-	pageSize      int
-	error         []error
+	term           string
+	sortKeys       []SortKey
+	setup          Setup
+	configurations []Configuration
+	holdSets       []HoldSet
+	filters        []Filter
+	minGrade       Grade
+	maxGrade       Grade
+	minGradeSet    bool
+	maxGradeSet    bool
+	page           int
+	pageSize       int
+	error          []error
 }
 
 // New creates a new QueryBuilder with a default min and max grade
@@ -138,7 +219,6 @@ func New() QueryBuilder {
 	qb := queryBuilder{
 		minGrade: FivePlus,
 		maxGrade: EightBPlus,
-		order:    "",
 		pageSize: 15,
 		page:     1,
 	}
@@ -149,155 +229,233 @@ func New() QueryBuilder {
 // the problem being searched for.
 // Default: empty string
 func (qb *queryBuilder) Term(searchTerm string) QueryBuilder {
-	qb.term = "Name~contains~'" + searchTerm + "'"
+	qb.term = searchTerm
 	return qb
 }
 
-// Sort sets the sort order that will be used in the query.
-// Only one sort order can be provided, if more than one is then
-// an error is added to be returned and the last provided Order is used.
-// Default: is Newest problems first
+// Sort adds a sort order that will be used in the query.
+// Calling Sort more than once adds secondary sort orders, applied in the
+// order they were provided. Sorting by the same Order twice adds an error
+// to be returned and the last provided direction is used.
+// Default: no sort orders, the sort is left empty and the website's own
+// ordering is used
 func (qb *queryBuilder) Sort(order Order, asc bool) QueryBuilder {
-	if qb.order != "" {
-		qb.error = append(qb.error, errors.New("can only sort by one parameter, defaulting to the last provided"))
-	}
-
-	switch order {
-	case Newest:
-		qb.order = string(order) + descString
-	case Difficulty:
-		qb.order = string(order) + ascending(asc)
-	case Rating:
-		qb.order = string(order) + descString
-	case Repeats:
-		qb.order = string(order) + ascending(asc)
+	key := SortKey{Order: order, Asc: asc}
+	for i, existing := range qb.sortKeys {
+		if existing.Order == order {
+			qb.error = append(qb.error, &BuildError{Field: "Sort", Value: string(order), Reason: "can only sort by " + string(order) + " once, defaulting to the last provided"})
+			qb.sortKeys[i] = key
+			return qb
+		}
 	}
+	qb.sortKeys = append(qb.sortKeys, key)
 	return qb
 }
 
-func ascending(asc bool) string {
-	if asc {
-		return "Asc" + ascString
-	}
-	return "Desc" + descString
+// Setup sets the hold setup of the board being searched.
+// The website takes the setup from the user's profile so it is not sent
+// with the query, it is used to check the configurations and hold sets
+// requested are available on that board.
+// Default: is any setup
+func (qb *queryBuilder) Setup(setup Setup) QueryBuilder {
+	qb.setup = setup
+	return qb
 }
 
 // Configuration sets the angle configuration of the board to use.
 // Default: is all board configurations (40 and 20 degree)
 func (qb *queryBuilder) Configuration(filter Configuration) QueryBuilder {
-	unescaped, err := url.QueryUnescape(string(filter))
-	if err != nil {
-		panic(err)
-	}
-	if qb.configuration == "" {
-		if filter == Forty {
-			qb.minGrade = SixAPlus
-		}
-		qb.configuration = "Configuration~eq~'" + string(filter) + "'"
-	} else {
-		qb.minGrade = FivePlus
-		qb.configuration = strings.TrimSuffix(qb.configuration, "'") + "," + unescaped + "'"
-	}
+	qb.configurations = append(qb.configurations, filter)
 	return qb
 }
 
 // HoldSet sets the hold sets that problems can include.
 // Default: is all hold sets
 func (qb *queryBuilder) HoldSet(filter HoldSet) QueryBuilder {
-	var buffer bytes.Buffer
-	if qb.holdSet == "" {
-		buffer.WriteString("Holdsets~eq~'")
-		buffer.WriteString(string(filter))
-		buffer.WriteString("'")
-		qb.holdSet = buffer.String()
-	} else {
-		qb.holdSet = strings.TrimSuffix(qb.holdSet, "'") + "," + string(filter) + "'"
-	}
+	qb.holdSets = append(qb.holdSets, filter)
 	return qb
 }
 
 // Filter specifies how to filter problems.
 // Default: is not to filter
 func (qb *queryBuilder) Filter(filter Filter) QueryBuilder {
-	var buffer bytes.Buffer
-	if qb.filter == "" {
-		buffer.WriteString(string(filter))
-		buffer.WriteString("~eq~''")
-	} else {
-		buffer.WriteString(qb.filter)
-		addAnd(&buffer, string(filter))
-		buffer.WriteString("~eq~''")
-	}
-
-	qb.filter = buffer.String()
-
+	qb.filters = append(qb.filters, filter)
 	return qb
 }
 
 // MinGrade sets the mininum grade for the problems being searched.
-// Default: FivePlus unless configuration is set to only Forty
+// Default: the lowest grade supported by the selected boards, FivePlus
+// unless configuration is set to only Forty
 func (qb *queryBuilder) MinGrade(min Grade) QueryBuilder {
 	qb.minGrade = min
+	qb.minGradeSet = true
 	return qb
 }
 
 // MaxGrade sets the maximum grade for the problems being searched.
-// Default: EightBPlus
+// Default: the highest grade supported by the selected boards, EightBPlus
 func (qb *queryBuilder) MaxGrade(max Grade) QueryBuilder {
 	qb.maxGrade = max
+	qb.maxGradeSet = true
 	return qb
 }
 
 // Page specifies which page of results to return
 func (qb *queryBuilder) Page(page int) QueryBuilder {
 	if page < 1 {
-		qb.error = append(qb.error, errors.New("page number cannot be below 1"))
+		qb.error = append(qb.error, &BuildError{Field: "Page", Value: strconv.Itoa(page), Reason: "page number cannot be below 1"})
 	} else {
 		qb.page = page
 	}
 	return qb
 }
 
+// MaxPageSize is the most problems the website returns in a single page.
+const MaxPageSize = 100
+
 // PageSize specifies the number of results to return per page
 func (qb *queryBuilder) PageSize(pageSize int) QueryBuilder {
-	if pageSize > 100 || pageSize < 1 {
-		qb.error = append(qb.error, errors.New("Page size must be between 1 and 100"))
+	if pageSize > MaxPageSize || pageSize < 1 {
+		qb.error = append(qb.error, &BuildError{Field: "PageSize", Value: strconv.Itoa(pageSize), Reason: "Page size must be between 1 and " + strconv.Itoa(MaxPageSize)})
 	} else {
 		qb.pageSize = pageSize
 	}
 	return qb
 }
 
+// Definition returns the structured values set on the builder so far.
+func (qb *queryBuilder) Definition() Definition {
+	d := Definition{
+		Version:        DefinitionVersion,
+		Term:           qb.term,
+		Sort:           append([]SortKey(nil), qb.sortKeys...),
+		Setup:          qb.setup,
+		Configurations: append([]Configuration(nil), qb.configurations...),
+		HoldSets:       append([]HoldSet(nil), qb.holdSets...),
+		Filters:        append([]Filter(nil), qb.filters...),
+		Page:           qb.page,
+		PageSize:       qb.pageSize,
+	}
+	if qb.minGradeSet {
+		min := qb.minGrade
+		d.MinGrade = &min
+	}
+	if qb.maxGradeSet {
+		max := qb.maxGrade
+		d.MaxGrade = &max
+	}
+	return d
+}
+
+// MarshalJSON writes the builder as its Definition.
+func (qb *queryBuilder) MarshalJSON() ([]byte, error) {
+	return json.Marshal(qb.Definition())
+}
+
+// UnmarshalJSON replaces the values set on the builder with those from a
+// JSON Definition.
+func (qb *queryBuilder) UnmarshalJSON(data []byte) error {
+	var d Definition
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err
+	}
+	*qb = *FromDefinition(d).(*queryBuilder)
+	return nil
+}
+
+// MarshalYAML writes the builder as its Definition.
+func (qb *queryBuilder) MarshalYAML() (interface{}, error) {
+	return qb.Definition(), nil
+}
+
+// UnmarshalYAML replaces the values set on the builder with those from a
+// YAML Definition.
+func (qb *queryBuilder) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var d Definition
+	if err := unmarshal(&d); err != nil {
+		return err
+	}
+	*qb = *FromDefinition(d).(*queryBuilder)
+	return nil
+}
+
 // Build takes the queryBuilder and constructs a query.
 // All set values from the queryBuilder are converted to strings and added to either
 // sort or fitler parameters of a query. This is the format required by the website.
 // An array of errors are returned if any of the values set are invalid.
 func (qb *queryBuilder) Build() (Query, []error) {
 
-	if qb.minGrade > qb.maxGrade {
-		qb.error = append(qb.error, errors.New("min grade cannot be higher than max grade"))
+	errs := append([]error(nil), qb.error...)
+
+	minGrade, maxGrade := qb.minGrade, qb.maxGrade
+	bounds, err := gradeBounds(qb.setup, qb.configurations)
+	if err == nil {
+		if !qb.minGradeSet {
+			minGrade = bounds.Min
+		} else if minGrade < bounds.Min {
+			errs = append(errs, &BuildError{Field: "MinGrade", Value: gradeStrings[minGrade], Reason: "min grade " + gradeStrings[minGrade] +
+				" is below the lowest grade supported by " + describeLayouts(qb.setup, qb.configurations) + " (" + gradeStrings[bounds.Min] + ")"})
+		}
+
+		if !qb.maxGradeSet {
+			maxGrade = bounds.Max
+		} else if maxGrade > bounds.Max {
+			errs = append(errs, &BuildError{Field: "MaxGrade", Value: gradeStrings[maxGrade], Reason: "max grade " + gradeStrings[maxGrade] +
+				" is above the highest grade supported by " + describeLayouts(qb.setup, qb.configurations) + " (" + gradeStrings[bounds.Max] + ")"})
+		}
+	}
+
+	if minGrade > maxGrade {
+		errs = append(errs, &BuildError{Field: "MinGrade", Value: gradeStrings[minGrade], Reason: "min grade cannot be higher than max grade"})
 	}
 
+	errs = append(errs, validateLayout(qb.setup, qb.configurations, qb.holdSets)...)
+
 	var buffer bytes.Buffer
-	buffer.WriteString(qb.configuration)
+	if len(qb.configurations) > 0 {
+		configs := make([]string, len(qb.configurations))
+		for i, config := range qb.configurations {
+			configs[i] = string(config)
+		}
+		buffer.WriteString("Configuration~eq~'" + strings.Join(configs, ",") + "'")
+	}
+
+	if qb.term != "" {
+		addAnd(&buffer, "Name~contains~'"+qb.term+"'")
+	}
+
+	if len(qb.holdSets) > 0 {
+		sets := make([]string, len(qb.holdSets))
+		for i, set := range qb.holdSets {
+			sets[i] = string(set)
+		}
+		addAnd(&buffer, "Holdsets~eq~'"+strings.Join(sets, ",")+"'")
+	}
+
+	for _, filter := range qb.filters {
+		addAnd(&buffer, string(filter)+"~eq~''")
+	}
 
-	addAnd(&buffer, qb.term)
-	addAnd(&buffer, qb.holdSet)
-	addAnd(&buffer, qb.filter)
-	addAnd(&buffer, strings.Replace("MinGrade~eq~'5+'", "5+", gradeStrings[qb.minGrade], -1))
-	addAnd(&buffer, strings.Replace("MaxGrade~eq~'8B+'", "8B+", gradeStrings[qb.maxGrade], -1))
+	addAnd(&buffer, strings.Replace("MinGrade~eq~'5+'", "5+", gradeStrings[minGrade], -1))
+	addAnd(&buffer, strings.Replace("MaxGrade~eq~'8B+'", "8B+", gradeStrings[maxGrade], -1))
 
 	filterString := buffer.String()
 
+	keys := make([]string, len(qb.sortKeys))
+	for i, key := range qb.sortKeys {
+		keys[i] = key.String()
+	}
+
 	query := &query{
-		sort:     qb.order,
-		filter:   filterString,
-		page:     qb.page,
-		pageSize: qb.pageSize,
+		sort:       strings.Join(keys, "~"),
+		filter:     filterString,
+		page:       qb.page,
+		pageSize:   qb.pageSize,
+		definition: qb.Definition(),
 	}
 
-	if len(qb.error) > 0 {
-		return query, qb.error
+	if len(errs) > 0 {
+		return query, errs
 	}
 
 	return query, nil
@@ -315,25 +473,38 @@ func isValidGrade(grade string) bool {
 	return match
 }
 
+// orderNames maps the name of each Order, in lower case, to its value.
+var orderNames = map[string]Order{
+	"new":          Newest,
+	"grade":        Difficulty,
+	"rating":       Rating,
+	"repeats":      Repeats,
+	"name":         Name,
+	"setter":       SetterName,
+	"dateinserted": DateInserted,
+	"userrating":   UserRating,
+}
+
 // ToOrder takes a string and returns its corresponding Order value
 // errors if the string passed is not valid
 func ToOrder(order string) (*Order, error) {
-	var orderType Order
-	switch strings.ToLower(order) {
-	case "new":
-		orderType = Newest
-	case "grade":
-		orderType = Difficulty
-	case "rating":
-		orderType = Rating
-	case "repeats":
-		orderType = Repeats
-	default:
+	orderType, ok := orderNames[strings.ToLower(order)]
+	if !ok {
 		return nil, errors.New("String passed to ToOrder was not a valid order value")
 	}
 	return &orderType, nil
 }
 
+// isOrder returns true if order is one of the Order values.
+func isOrder(order Order) bool {
+	for _, known := range orderNames {
+		if known == order {
+			return true
+		}
+	}
+	return false
+}
+
 // ToConfiguration takes a string and returns its corresponding Configuration value
 // errors if the string passed is not valid
 func ToConfiguration(config string) (*Configuration, error) {
@@ -350,44 +521,66 @@ func ToConfiguration(config string) (*Configuration, error) {
 	return &configType, nil
 }
 
+// holdSetNames maps the name of each HoldSet, in lower case, to its value.
+var holdSetNames = map[string]HoldSet{
+	"os":    OS,
+	"wood":  Wood,
+	"woodb": WoodB,
+	"woodc": WoodC,
+	"a":     A,
+	"b":     B,
+	"c":     C,
+	"d":     D,
+	"e":     E,
+}
+
 // ToHoldSet takes a string and returns its corresponding HoldSet value
 // errors if the string passed is not valid
 func ToHoldSet(holdSet string) (*HoldSet, error) {
-	var holdSetType HoldSet
-	switch strings.ToLower(holdSet) {
-	case "os":
-		holdSetType = OS
-	case "wood":
-		holdSetType = Wood
-	case "a":
-		holdSetType = A
-	case "b":
-		holdSetType = B
-	case "c":
-		holdSetType = C
-	default:
+	holdSetType, ok := holdSetNames[strings.ToLower(holdSet)]
+	if !ok {
 		return nil, errors.New("String passed to ToHoldSet was not a valid Hold Set")
 	}
 	return &holdSetType, nil
 }
 
+// isHoldSet returns true if holdSet is one of the HoldSet values.
+func isHoldSet(holdSet HoldSet) bool {
+	for _, known := range holdSetNames {
+		if known == holdSet {
+			return true
+		}
+	}
+	return false
+}
+
+// filterNames maps the name of each Filter, in lower case, to its value.
+var filterNames = map[string]Filter{
+	"benchmarks": Benchmarks,
+	"setbyme":    SetByMe,
+	"myascents":  MyAscents,
+}
+
 // ToFilter takes a string and returns its corresponding Filter value
 // errors if the string passed is not valid
 func ToFilter(filter string) (*Filter, error) {
-	var filterType Filter
-	switch strings.ToLower(filter) {
-	case "benchmarks":
-		filterType = Benchmarks
-	case "setbyme":
-		filterType = SetByMe
-	case "myascents":
-		filterType = MyAscents
-	default:
+	filterType, ok := filterNames[strings.ToLower(filter)]
+	if !ok {
 		return nil, errors.New("String passed to ToFilter was not a valid Filter")
 	}
 	return &filterType, nil
 }
 
+// isFilter returns true if filter is one of the Filter values.
+func isFilter(filter Filter) bool {
+	for _, known := range filterNames {
+		if known == filter {
+			return true
+		}
+	}
+	return false
+}
+
 // ToGrade takes a string and returns its corresponding Grade value
 // errors if the string passed is not valid
 func ToGrade(grade string) (*Grade, error) {
@@ -14,7 +14,7 @@ func TestBuilderSortNewest(t *testing.T) {
 }
 
 func TestBuilderSortDifficulty(t *testing.T) {
-	expected := "GradeAsc-asc"
+	expected := "Grade-asc"
 	builder := New()
 	query, err := builder.Sort(Difficulty, true).Build()
 
@@ -28,7 +28,7 @@ func TestBuilderSortDifficulty(t *testing.T) {
 	}
 
 	builder = New()
-	expected = "GradeDesc-desc"
+	expected = "Grade-desc"
 	query, err = builder.Sort(Difficulty, false).Build()
 
 	if err != nil {
@@ -51,7 +51,7 @@ func TestBuilderSortRating(t *testing.T) {
 }
 
 func TestBuilderSortRepeats(t *testing.T) {
-	expected := "RepeatsAsc-asc"
+	expected := "Repeats-asc"
 	builder := New()
 	query, err := builder.Sort(Repeats, true).Build()
 
@@ -65,7 +65,7 @@ func TestBuilderSortRepeats(t *testing.T) {
 	}
 
 	builder = New()
-	expected = "RepeatsDesc-desc"
+	expected = "Repeats-desc"
 	query, err = builder.Sort(Repeats, false).Build()
 
 	if err != nil {
@@ -78,19 +78,43 @@ func TestBuilderSortRepeats(t *testing.T) {
 	}
 }
 
-func TestBuilderOnlyOneSort(t *testing.T) {
+func TestBuilderSortNewestAscending(t *testing.T) {
+	expected := "New-asc"
+	builder := New()
+	query, _ := builder.Sort(Newest, true).Build()
+	if query.Sort() != expected {
+		t.Errorf("Query sort was incorrect, got %s, want: %s", query.Sort(), expected)
+	}
+}
 
-	expected := "New-desc"
+func TestBuilderSortMultiple(t *testing.T) {
+	expected := "Grade-asc~UserRating-desc~Name-asc"
+	builder := New()
+	query, err := builder.Sort(Difficulty, true).Sort(UserRating, false).Sort(Name, true).Build()
+
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err[0].Error())
+		t.FailNow()
+	}
+
+	if query.Sort() != expected {
+		t.Errorf("Query sort was incorrect, got %s, want: %s", query.Sort(), expected)
+	}
+}
+
+func TestBuilderOnlySortByOrderOnce(t *testing.T) {
+
+	expected := "Repeats-desc~New-desc"
 
 	builder := New()
-	query, err := builder.Sort(Repeats, true).Sort(Newest, false).Build()
+	query, err := builder.Sort(Repeats, true).Sort(Newest, false).Sort(Repeats, false).Build()
 
 	if err == nil {
 		t.Errorf("Expected error not recieved")
 		t.FailNow()
 	}
 
-	expectedError := "can only sort by one parameter, defaulting to the last provided"
+	expectedError := "can only sort by Repeats once, defaulting to the last provided"
 	if err[0].Error() != expectedError {
 		t.Errorf("Incorrect error provided. Got: %s Expected: %s", err[0].Error(), expectedError)
 	}
@@ -389,3 +413,63 @@ func TestToGradeErrorsOnInvalidValue(t *testing.T) {
 		t.Error("Expected error not recieved")
 	}
 }
+
+func TestBuilderConfigurationKeepsExplicitMinGrade(t *testing.T) {
+	expected := "Configuration~eq~'40° MoonBoard'~and~MinGrade~eq~'7A'~and~MaxGrade~eq~'8B+'"
+	builder := New()
+	query, err := builder.MinGrade(SevenA).Configuration(Forty).Build()
+
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err[0].Error())
+		t.FailNow()
+	}
+
+	if query.Filter() != expected {
+		t.Errorf("Query Filter was incorrect, got %s, want: %s", query.Filter(), expected)
+	}
+}
+
+func TestBuilderMinGradeBelowConfigurationErrors(t *testing.T) {
+	expectedError := "min grade 6A is below the lowest grade supported by 40° MoonBoard (6A+)"
+	builder := New()
+	_, err := builder.Configuration(Forty).MinGrade(SixA).Build()
+
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+		t.FailNow()
+	}
+
+	if err[0].Error() != expectedError {
+		t.Errorf("Incorrect error provided. Got: %s Expected: %s", err[0].Error(), expectedError)
+	}
+}
+
+func TestBuilderMaxGradeDefaultsToSetup(t *testing.T) {
+	expected := "MinGrade~eq~'5+'~and~MaxGrade~eq~'8A+'"
+	builder := New()
+	query, err := builder.Setup(Mini2020).Build()
+
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err[0].Error())
+		t.FailNow()
+	}
+
+	if query.Filter() != expected {
+		t.Errorf("Query Filter was incorrect, got %s, want: %s", query.Filter(), expected)
+	}
+}
+
+func TestBuilderMaxGradeAboveSetupErrors(t *testing.T) {
+	expectedError := "max grade 8B is above the highest grade supported by Mini MoonBoard 2020 (8A+)"
+	builder := New()
+	_, err := builder.Setup(Mini2020).MaxGrade(EightB).Build()
+
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+		t.FailNow()
+	}
+
+	if err[0].Error() != expectedError {
+		t.Errorf("Incorrect error provided. Got: %s Expected: %s", err[0].Error(), expectedError)
+	}
+}
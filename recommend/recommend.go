@@ -0,0 +1,201 @@
+// Package recommend suggests problems for a climber to try next based on
+// the problems they have already climbed.
+package recommend
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/query"
+	"github.com/cstdev/moonapi/similarity"
+)
+
+// maxRating is the highest star rating a problem can have.
+const maxRating = 3
+
+// Weights sets how much each quality of a problem counts towards it being
+// recommended. Diversity rewards problems unlike those already suggested,
+// in their holds and setter.
+type Weights struct {
+	Rating    float64
+	Repeats   float64
+	Grade     float64
+	Diversity float64
+}
+
+// DefaultWeights favours well rated problems and keeps the suggestions
+// varied.
+var DefaultWeights = Weights{Rating: 1, Repeats: 0.5, Grade: 0.5, Diversity: 1}
+
+// Options changes which problems are recommended.
+// GradesBelow and GradesAbove set the window of grades around the hardest
+// grade climbed to recommend from. Limit is the number of suggestions.
+type Options struct {
+	Weights     Weights
+	GradesBelow int
+	GradesAbove int
+	Limit       int
+}
+
+// DefaultOptions recommends 10 problems from a grade below to a grade above
+// the hardest grade climbed.
+var DefaultOptions = Options{Weights: DefaultWeights, GradesBelow: 1, GradesAbove: 1, Limit: 10}
+
+// Recommendation is a suggested problem, its score and the reasons it was
+// suggested.
+type Recommendation struct {
+	Problem moonapi.Problem
+	Score   float64
+	Reasons []string
+}
+
+type candidate struct {
+	problem moonapi.Problem
+	grade   query.Grade
+	score   float64
+	reasons []string
+	// overlap is how alike the problem is to the most similar problem
+	// picked so far.
+	overlap float64
+}
+
+// Recommend suggests problems from problems that haven't been climbed yet,
+// such as those fetched with the query.MyAscents filter.
+// Problems within the grade window around the hardest ascent are scored by
+// their rating, repeats and how close they are to the hardest grade, then
+// picked one at a time, favouring problems unlike those already picked.
+// errors if none of the ascents have a known grade
+func Recommend(ascents []moonapi.Problem, problems []moonapi.Problem, options Options) ([]Recommendation, error) {
+	if options.GradesBelow < 0 || options.GradesAbove < 0 {
+		return nil, errors.New("grade window cannot be negative")
+	}
+
+	climbed := map[int]bool{}
+	hardest, found := query.FivePlus, false
+	for _, ascent := range ascents {
+		climbed[ascent.ID] = true
+		if grade, ok := gradeOf(ascent); ok && (!found || grade > hardest) {
+			hardest, found = grade, true
+		}
+	}
+	if !found {
+		return nil, errors.New("no ascents with a known grade to recommend from")
+	}
+
+	low, high := hardest-query.Grade(options.GradesBelow), hardest+query.Grade(options.GradesAbove)
+	mostRepeats := 0
+	var candidates []*candidate
+	for _, problem := range problems {
+		grade, ok := gradeOf(problem)
+		if !ok || climbed[problem.ID] || grade < low || grade > high {
+			continue
+		}
+		if problem.Repeats > mostRepeats {
+			mostRepeats = problem.Repeats
+		}
+		candidates = append(candidates, &candidate{problem: problem, grade: grade})
+	}
+
+	window := float64(options.GradesBelow + options.GradesAbove + 1)
+	for _, c := range candidates {
+		c.score, c.reasons = score(c, hardest, window, mostRepeats, options.Weights)
+	}
+
+	return pick(candidates, options), nil
+}
+
+// score rates a problem on its own, without comparing it to others picked.
+func score(c *candidate, hardest query.Grade, window float64, mostRepeats int, weights Weights) (float64, []string) {
+	var total float64
+	var reasons []string
+
+	total += weights.Rating * float64(c.problem.Rating) / maxRating
+	if c.problem.Rating > 0 {
+		reasons = append(reasons, fmt.Sprintf("rated %d of %d stars", c.problem.Rating, maxRating))
+	}
+
+	if mostRepeats > 0 {
+		total += weights.Repeats * math.Log1p(float64(c.problem.Repeats)) / math.Log1p(float64(mostRepeats))
+	}
+	if c.problem.Repeats > 0 {
+		reasons = append(reasons, fmt.Sprintf("repeated %d times", c.problem.Repeats))
+	}
+
+	distance := c.grade - hardest
+	total += weights.Grade * (1 - math.Abs(float64(distance))/window)
+	switch {
+	case distance > 0:
+		reasons = append(reasons, fmt.Sprintf("%s is %s above your hardest, %s", c.grade, grades(int(distance)), hardest))
+	case distance < 0:
+		reasons = append(reasons, fmt.Sprintf("%s is %s below your hardest, %s", c.grade, grades(int(-distance)), hardest))
+	default:
+		reasons = append(reasons, fmt.Sprintf("%s matches your hardest grade", c.grade))
+	}
+
+	if c.problem.IsBenchmark {
+		reasons = append(reasons, "benchmark")
+	}
+	return total, reasons
+}
+
+// pick chooses the best candidates one at a time. Each pick is scored on its
+// own and on how unlike the problems already picked it is, so suggestions
+// don't all share the same holds or setter.
+func pick(candidates []*candidate, options Options) []Recommendation {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	recommendations := []Recommendation{}
+	for len(candidates) > 0 && (options.Limit <= 0 || len(recommendations) < options.Limit) {
+		best, bestScore := 0, math.Inf(-1)
+		for i, c := range candidates {
+			total := c.score + options.Weights.Diversity*(1-c.overlap)
+			if total > bestScore {
+				best, bestScore = i, total
+			}
+		}
+
+		c := candidates[best]
+		reasons := c.reasons
+		if len(recommendations) > 0 && c.overlap < 0.5 {
+			reasons = append(reasons, "different to your other suggestions")
+		}
+		recommendations = append(recommendations, Recommendation{Problem: c.problem, Score: bestScore, Reasons: reasons})
+
+		candidates = append(candidates[:best], candidates[best+1:]...)
+		for _, other := range candidates {
+			if alike := overlap(other.problem, c.problem); alike > other.overlap {
+				other.overlap = alike
+			}
+		}
+	}
+	return recommendations
+}
+
+// overlap returns how alike two problems are from 0 to 1, half from their
+// holds and half from sharing a setter.
+func overlap(a moonapi.Problem, b moonapi.Problem) float64 {
+	alike := similarity.Score(a, b) / 2
+	if a.Setter.Nickname != "" && strings.EqualFold(a.Setter.Nickname, b.Setter.Nickname) {
+		alike += 0.5
+	}
+	return alike
+}
+
+func gradeOf(problem moonapi.Problem) (query.Grade, bool) {
+	var grade query.Grade
+	err := grade.UnmarshalText([]byte(problem.Grade))
+	return grade, err == nil
+}
+
+func grades(n int) string {
+	if n == 1 {
+		return "a grade"
+	}
+	return fmt.Sprintf("%d grades", n)
+}
@@ -0,0 +1,125 @@
+package recommend
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/internal/problemtest"
+)
+
+func testProblem(id int, grade string, rating int, repeats int, setter string, holds ...string) moonapi.Problem {
+	problem := problemtest.Problem(id, nil, holds, nil)
+	problem.Grade = grade
+	problem.Rating = rating
+	problem.Repeats = repeats
+	problem.Setter.Nickname = setter
+	return problem
+}
+
+func testProblems() []moonapi.Problem {
+	return []moonapi.Problem{
+		testProblem(1, "6C", 3, 500, "Ben", "F5", "G8", "F18"),
+		testProblem(2, "6C+", 3, 400, "Ben", "F5", "G8", "F18"),
+		testProblem(3, "7A", 3, 300, "Ben", "F5", "G8", "E18"),
+		testProblem(4, "7A", 2, 50, "Alex", "A5", "B9", "C18"),
+		testProblem(5, "7B", 3, 900, "Sam", "K5", "J9", "K18"),
+		testProblem(6, "6B+", 1, 10, "Sam", "D5", "E9", "D18"),
+		testProblem(7, "5+", 3, 1000, "Alex", "H5", "H9", "H18"),
+	}
+}
+
+func ids(recommendations []Recommendation) []int {
+	var out []int
+	for _, r := range recommendations {
+		out = append(out, r.Problem.ID)
+	}
+	return out
+}
+
+func TestRecommendStaysInGradeWindow(t *testing.T) {
+	problems := testProblems()
+	ascents := []moonapi.Problem{problems[0], problems[1]}
+
+	recommendations, err := Recommend(ascents, problems, DefaultOptions)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	got := ids(recommendations)
+	if len(got) != 2 {
+		t.Errorf("Expected only the unclimbed 7A problems, got %v", got)
+	}
+	for _, id := range got {
+		if id != 3 && id != 4 {
+			t.Errorf("Expected only the unclimbed 7A problems, got %v", got)
+		}
+	}
+}
+
+func TestRecommendFavoursRating(t *testing.T) {
+	problems := testProblems()
+	ascents := []moonapi.Problem{problems[0], problems[1]}
+	options := DefaultOptions
+	options.Weights.Diversity = 0
+
+	recommendations, _ := Recommend(ascents, problems, options)
+
+	if len(recommendations) == 0 || recommendations[0].Problem.ID != 3 {
+		t.Errorf("Expected the 3 star problem first, got %v", ids(recommendations))
+	}
+}
+
+func TestRecommendFavoursDiversity(t *testing.T) {
+	problems := testProblems()
+	problems = append(problems, testProblem(8, "7A", 3, 300, "Ben", "F5", "G8", "F18"))
+	ascents := []moonapi.Problem{problems[0], problems[1]}
+	options := DefaultOptions
+	options.Limit = 2
+
+	recommendations, _ := Recommend(ascents, problems, options)
+	got := ids(recommendations)
+
+	if len(got) != 2 || got[1] != 4 {
+		t.Errorf("Expected a problem by a different setter second, got %v", got)
+	}
+
+	found := false
+	for _, reason := range recommendations[1].Reasons {
+		if reason == "different to your other suggestions" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected diversity to be given as a reason, got %v", recommendations[1].Reasons)
+	}
+}
+
+func TestRecommendExplains(t *testing.T) {
+	problems := testProblems()
+	ascents := []moonapi.Problem{problems[0], problems[1]}
+
+	recommendations, _ := Recommend(ascents, problems, DefaultOptions)
+	reasons := strings.Join(recommendations[0].Reasons, ", ")
+
+	if reasons != "rated 3 of 3 stars, repeated 300 times, 7A is a grade above your hardest, 6C+" {
+		t.Errorf("Unexpected reasons, got %s", reasons)
+	}
+}
+
+func TestRecommendWithoutAscents(t *testing.T) {
+	if _, err := Recommend(nil, testProblems(), DefaultOptions); err == nil {
+		t.Errorf("Expected an error with no ascents")
+	}
+}
+
+func TestRecommendNegativeWindow(t *testing.T) {
+	problems := testProblems()
+	options := DefaultOptions
+	options.GradesBelow = -1
+
+	if _, err := Recommend(problems[:1], problems, options); err == nil {
+		t.Errorf("Expected an error with a negative grade window")
+	}
+}
@@ -0,0 +1,109 @@
+package render
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg" // board photos are usually JPEGs
+	"image/png"
+	"io"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/holds"
+)
+
+// Calibration lines the grid up with a photo of the board by giving the
+// pixel centres of the top left hold, A18 or A12 on a mini board, and the
+// bottom right hold, K1.
+type Calibration struct {
+	TopLeft     image.Point
+	BottomRight image.Point
+}
+
+// Image draws the problem on an empty grid with a ring round each hold.
+// Images have no text, so Labels and Header are ignored.
+func Image(problem moonapi.Problem, options Options) *image.RGBA {
+	options.Header = false
+	b := layout(problem, options)
+	theme := options.Theme
+
+	img := image.NewRGBA(image.Rect(0, 0, b.width, b.height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{theme.Background}, image.Point{}, draw.Src)
+
+	grid := &image.Uniform{theme.Grid}
+	for column := 0; column <= holds.Columns; column++ {
+		x := b.left + column*b.cell
+		draw.Draw(img, image.Rect(x, b.top, x+1, b.top+b.rows*b.cell+1), grid, image.Point{}, draw.Src)
+	}
+	for row := 0; row <= b.rows; row++ {
+		y := b.top + row*b.cell
+		draw.Draw(img, image.Rect(b.left, y, b.left+holds.Columns*b.cell+1, y+1), grid, image.Point{}, draw.Src)
+	}
+
+	for _, hold := range b.holdsOf(problem) {
+		x, y := b.centre(hold.Position)
+		ring(img, image.Pt(x, y), b.cell*2/5, maxInt(b.cell/12, 1), theme.colour(hold))
+	}
+	return img
+}
+
+// ImageOver draws the problem over a photo of the board, using the
+// calibration to find each hold. Only the hold colours of the theme are
+// used.
+// errors if the calibrated holds are not inside the photo or the
+// bottom right hold is not below and right of the top left
+func ImageOver(background image.Image, calibration Calibration, problem moonapi.Problem, options Options) (*image.RGBA, error) {
+	bounds := background.Bounds()
+	if !calibration.TopLeft.In(bounds) || !calibration.BottomRight.In(bounds) {
+		return nil, errors.New("calibrated holds must be inside the background image")
+	}
+	if calibration.BottomRight.X <= calibration.TopLeft.X || calibration.BottomRight.Y <= calibration.TopLeft.Y {
+		return nil, errors.New("bottom right hold must be below and to the right of the top left hold")
+	}
+
+	b := layout(problem, options)
+	img := image.NewRGBA(bounds)
+	draw.Draw(img, bounds, background, bounds.Min, draw.Src)
+
+	spacingX := float64(calibration.BottomRight.X-calibration.TopLeft.X) / float64(holds.Columns-1)
+	spacingY := float64(calibration.BottomRight.Y-calibration.TopLeft.Y) / float64(b.rows-1)
+	spacing := spacingX
+	if spacingY < spacing {
+		spacing = spacingY
+	}
+
+	for _, hold := range b.holdsOf(problem) {
+		centre := image.Pt(
+			calibration.TopLeft.X+int(float64(hold.Column)*spacingX+0.5),
+			calibration.TopLeft.Y+int(float64(b.rows-hold.Row)*spacingY+0.5),
+		)
+		ring(img, centre, int(spacing*0.45), maxInt(int(spacing/10), 1), options.Theme.colour(hold))
+	}
+	return img, nil
+}
+
+// PNG writes the problem as a PNG image of the board.
+func PNG(w io.Writer, problem moonapi.Problem, options Options) error {
+	return png.Encode(w, Image(problem, options))
+}
+
+// LoadBackground reads a PNG or JPEG photo of the board to draw over.
+func LoadBackground(r io.Reader) (image.Image, error) {
+	img, _, err := image.Decode(r)
+	return img, err
+}
+
+// ring draws a circle outline of the given radius and thickness.
+func ring(img *image.RGBA, centre image.Point, radius int, thickness int, c color.RGBA) {
+	outer := radius * radius
+	inner := (radius - thickness) * (radius - thickness)
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			d := x*x + y*y
+			if d <= outer && d > inner {
+				img.SetRGBA(centre.X+x, centre.Y+y, c)
+			}
+		}
+	}
+}
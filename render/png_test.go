@@ -0,0 +1,97 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"testing"
+)
+
+func TestImage(t *testing.T) {
+	img := Image(testProblem(), Options{CellSize: 10, Theme: LightTheme, Header: true})
+
+	if img.Bounds().Dx() != 125 || img.Bounds().Dy() != 200 {
+		t.Errorf("Expected a 125 by 200 image without a header, got %v", img.Bounds())
+	}
+
+	// F5 is centred on 65,145 with a radius of 4.
+	if c := img.RGBAAt(69, 145); c != LightTheme.Start {
+		t.Errorf("Expected the start ring at 69,145, got %v", c)
+	}
+	if c := img.RGBAAt(65, 145); c != LightTheme.Background {
+		t.Errorf("Expected the middle of the ring to be empty, got %v", c)
+	}
+	if c := img.RGBAAt(119, 15); c != LightTheme.End {
+		t.Errorf("Expected the finish ring round K18, got %v", c)
+	}
+	if c := img.RGBAAt(10, 50); c != LightTheme.Grid {
+		t.Errorf("Expected a grid line at x 10, got %v", c)
+	}
+}
+
+func TestPNG(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := PNG(&buffer, testProblem(), DefaultOptions); err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	img, err := png.Decode(&buffer)
+	if err != nil {
+		t.Errorf("Expected a valid PNG, got %s", err.Error())
+		t.FailNow()
+	}
+	if img.Bounds().Dx() != 500 {
+		t.Errorf("Expected a 500 pixel wide image, got %d", img.Bounds().Dx())
+	}
+}
+
+func photo() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 220, 360))
+	draw.Draw(img, img.Bounds(), &image.Uniform{color.RGBA{0x80, 0x80, 0x80, 0xff}}, image.Point{}, draw.Src)
+	return img
+}
+
+func TestImageOver(t *testing.T) {
+	calibration := Calibration{TopLeft: image.Pt(10, 10), BottomRight: image.Pt(210, 350)}
+
+	img, err := ImageOver(photo(), calibration, testProblem(), DefaultOptions)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	// Holds are 20 pixels apart, F5 is centred on 110,270 with a radius of 9.
+	if c := img.RGBAAt(119, 270); c != LightTheme.Start {
+		t.Errorf("Expected the start ring at 119,270, got %v", c)
+	}
+	if c := img.RGBAAt(110, 270); c != (color.RGBA{0x80, 0x80, 0x80, 0xff}) {
+		t.Errorf("Expected the photo inside the ring, got %v", c)
+	}
+	if c := img.RGBAAt(210, 19); c != LightTheme.End {
+		t.Errorf("Expected the finish ring round K18, got %v", c)
+	}
+}
+
+func TestImageOverBadCalibration(t *testing.T) {
+	for _, calibration := range []Calibration{
+		{TopLeft: image.Pt(10, 10), BottomRight: image.Pt(400, 350)},
+		{TopLeft: image.Pt(210, 350), BottomRight: image.Pt(10, 10)},
+	} {
+		if _, err := ImageOver(photo(), calibration, testProblem(), DefaultOptions); err == nil {
+			t.Errorf("Expected an error for %+v", calibration)
+		}
+	}
+}
+
+func TestLoadBackground(t *testing.T) {
+	var buffer bytes.Buffer
+	png.Encode(&buffer, photo())
+
+	img, err := LoadBackground(&buffer)
+	if err != nil || img.Bounds().Dx() != 220 {
+		t.Errorf("Expected to load the photo back, got %v", err)
+	}
+}
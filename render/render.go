@@ -0,0 +1,137 @@
+// Package render draws problems on the board as SVG, PNG or text.
+package render
+
+import (
+	"image/color"
+	"strings"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/holds"
+	"github.com/cstdev/moonapi/query"
+)
+
+// Theme sets the colours a problem is drawn in.
+type Theme struct {
+	Background color.RGBA
+	Grid       color.RGBA
+	Text       color.RGBA
+	Start      color.RGBA
+	Move       color.RGBA
+	End        color.RGBA
+}
+
+// LightTheme draws on white, as for printing.
+var LightTheme = Theme{
+	Background: color.RGBA{0xff, 0xff, 0xff, 0xff},
+	Grid:       color.RGBA{0xcc, 0xcc, 0xcc, 0xff},
+	Text:       color.RGBA{0x22, 0x22, 0x22, 0xff},
+	Start:      color.RGBA{0x00, 0xb0, 0x00, 0xff},
+	Move:       color.RGBA{0x00, 0x55, 0xff, 0xff},
+	End:        color.RGBA{0xe0, 0x00, 0x00, 0xff},
+}
+
+// DarkTheme draws on black, as for screens.
+var DarkTheme = Theme{
+	Background: color.RGBA{0x11, 0x11, 0x11, 0xff},
+	Grid:       color.RGBA{0x44, 0x44, 0x44, 0xff},
+	Text:       color.RGBA{0xee, 0xee, 0xee, 0xff},
+	Start:      color.RGBA{0x33, 0xee, 0x33, 0xff},
+	Move:       color.RGBA{0x44, 0x88, 0xff, 0xff},
+	End:        color.RGBA{0xff, 0x44, 0x44, 0xff},
+}
+
+// Options changes how a problem is drawn.
+// CellSize is the width and height in pixels of each hold position.
+// Setup picks the size of the board, if empty it is read from the problem.
+// Labels writes the position of each hold next to it and Header writes the
+// problem's name, grade and setter above the board.
+type Options struct {
+	CellSize int
+	Theme    Theme
+	Setup    query.Setup
+	Labels   bool
+	Header   bool
+}
+
+// DefaultOptions draws a light board with labels and a header in 40 pixel
+// cells, 500 pixels wide including the margins.
+var DefaultOptions = Options{CellSize: 40, Theme: LightTheme, Labels: true, Header: true}
+
+// board is the size and position of the grid a problem is drawn on.
+type board struct {
+	rows   int
+	cell   int
+	left   int
+	top    int
+	width  int
+	height int
+}
+
+// layout works out where the grid sits in the image, leaving a margin for
+// the row numbers, column letters and header.
+func layout(problem moonapi.Problem, options Options) board {
+	setup := options.Setup
+	if setup == "" {
+		setup = query.Setup(problem.Holdsetup.Description)
+	}
+
+	b := board{rows: holds.RowsFor(setup), cell: options.CellSize}
+	if b.cell <= 0 {
+		b.cell = DefaultOptions.CellSize
+	}
+	b.left = b.cell
+	b.top = b.cell
+	if options.Header {
+		b.top += b.cell * 3 / 2
+	}
+	b.width = b.left + holds.Columns*b.cell + b.cell/2
+	b.height = b.top + b.rows*b.cell + b.cell
+	return b
+}
+
+// centre returns the pixel centre of a position on the board.
+func (b board) centre(p holds.Position) (int, int) {
+	return b.left + p.Column*b.cell + b.cell/2, b.top + (b.rows-p.Row)*b.cell + b.cell/2
+}
+
+// holdsOf returns the holds of the problem that fit on the board. Problems
+// without Moves fall back to the positions given in their Locations.
+func (b board) holdsOf(problem moonapi.Problem) []holds.Hold {
+	problemHolds := holds.Of(problem)
+	if len(problemHolds) == 0 {
+		for _, location := range problem.Locations {
+			description, _ := location.Description.(string)
+			if position, err := holds.ParsePosition(description); err == nil {
+				problemHolds = append(problemHolds, holds.Hold{Position: position})
+			}
+		}
+	}
+
+	var onBoard []holds.Hold
+	for _, hold := range problemHolds {
+		if hold.Row <= b.rows {
+			onBoard = append(onBoard, hold)
+		}
+	}
+	return onBoard
+}
+
+// colour returns the colour a hold is drawn in.
+func (t Theme) colour(hold holds.Hold) color.RGBA {
+	switch {
+	case hold.IsStart:
+		return t.Start
+	case hold.IsEnd:
+		return t.End
+	}
+	return t.Move
+}
+
+// title returns the name and grade of a problem, with the setter if known.
+func title(problem moonapi.Problem) string {
+	parts := []string{problem.Name, problem.Grade}
+	if problem.Setter.Nickname != "" {
+		parts = append(parts, "by "+problem.Setter.Nickname)
+	}
+	return strings.TrimSpace(strings.Join(parts, " "))
+}
@@ -0,0 +1,61 @@
+package render
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/holds"
+	"github.com/cstdev/moonapi/query"
+)
+
+func testProblem() moonapi.Problem {
+	problem := moonapi.Problem{
+		Name:  "Fat <Boy>",
+		Grade: "7A",
+		Moves: []moonapi.Move{
+			{Description: "F5", IsStart: true},
+			{Description: "G10"},
+			{Description: "K18", IsEnd: true},
+		},
+	}
+	problem.Setter.Nickname = "Ben"
+	problem.Holdsetup.Description = string(query.Masters2017)
+	return problem
+}
+
+func TestLayoutCentre(t *testing.T) {
+	b := layout(testProblem(), Options{CellSize: 10})
+
+	if x, y := b.centre(holds.Position{Column: 0, Row: 18}); x != 15 || y != 15 {
+		t.Errorf("Expected A18 at 15,15, got %d,%d", x, y)
+	}
+	if x, y := b.centre(holds.Position{Column: 10, Row: 1}); x != 115 || y != 185 {
+		t.Errorf("Expected K1 at 115,185, got %d,%d", x, y)
+	}
+	if b.width != 125 || b.height != 200 {
+		t.Errorf("Expected a 125 by 200 image, got %d by %d", b.width, b.height)
+	}
+}
+
+func TestHoldsOfSkipsHoldsOffTheBoard(t *testing.T) {
+	b := layout(testProblem(), Options{Setup: query.Mini2020})
+
+	if got := b.holdsOf(testProblem()); len(got) != 2 {
+		t.Errorf("Expected K18 to be left off the mini board, got %v", got)
+	}
+}
+
+func TestHoldsOfFallsBackToLocations(t *testing.T) {
+	var problem moonapi.Problem
+	err := json.Unmarshal([]byte(`{"Locations": [{"Description": "C7"}, {"Description": 12}]}`), &problem)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	got := layout(problem, DefaultOptions).holdsOf(problem)
+	if len(got) != 1 || got[0].String() != "C7" {
+		t.Errorf("Expected C7 from the locations, got %v", got)
+	}
+}
@@ -0,0 +1,83 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/holds"
+)
+
+// SVG writes the problem as an SVG image of the board with a circle round
+// each hold: green to start, blue for moves and red to finish.
+func SVG(w io.Writer, problem moonapi.Problem, options Options) error {
+	b := layout(problem, options)
+	theme := options.Theme
+	font := b.cell / 2
+
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`+"\n",
+		b.width, b.height, b.width, b.height)
+	fmt.Fprintf(&buffer, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hex(theme.Background))
+
+	if options.Header {
+		fmt.Fprintf(&buffer, `<text x="%d" y="%d" font-size="%d" font-weight="bold" fill="%s">%s</text>`+"\n",
+			b.left, b.cell, font*3/2, hex(theme.Text), html.EscapeString(title(problem)))
+		if subtitle := problem.Holdsetup.Description; subtitle != "" {
+			fmt.Fprintf(&buffer, `<text x="%d" y="%d" font-size="%d" fill="%s">%s</text>`+"\n",
+				b.left, b.cell+font*3/2, font*3/4, hex(theme.Text), html.EscapeString(subtitle))
+		}
+	}
+
+	fmt.Fprintf(&buffer, `<g stroke="%s" stroke-width="1">`+"\n", hex(theme.Grid))
+	for column := 0; column <= holds.Columns; column++ {
+		x := b.left + column*b.cell
+		fmt.Fprintf(&buffer, `<line x1="%d" y1="%d" x2="%d" y2="%d"/>`+"\n", x, b.top, x, b.top+b.rows*b.cell)
+	}
+	for row := 0; row <= b.rows; row++ {
+		y := b.top + row*b.cell
+		fmt.Fprintf(&buffer, `<line x1="%d" y1="%d" x2="%d" y2="%d"/>`+"\n", b.left, y, b.left+holds.Columns*b.cell, y)
+	}
+	fmt.Fprintln(&buffer, `</g>`)
+
+	fmt.Fprintf(&buffer, `<g font-size="%d" fill="%s" text-anchor="middle">`+"\n", font, hex(theme.Text))
+	for column := 0; column < holds.Columns; column++ {
+		x, _ := b.centre(holds.Position{Column: column, Row: 1})
+		fmt.Fprintf(&buffer, `<text x="%d" y="%d">%c</text>`+"\n", x, b.top+b.rows*b.cell+font*3/2, 'A'+column)
+	}
+	for row := 1; row <= b.rows; row++ {
+		_, y := b.centre(holds.Position{Row: row})
+		fmt.Fprintf(&buffer, `<text x="%d" y="%d">%d</text>`+"\n", b.left/2, y+font/3, row)
+	}
+	fmt.Fprintln(&buffer, `</g>`)
+
+	radius := b.cell * 2 / 5
+	for _, hold := range b.holdsOf(problem) {
+		x, y := b.centre(hold.Position)
+		fmt.Fprintf(&buffer, `<circle cx="%d" cy="%d" r="%d" fill="none" stroke="%s" stroke-width="%d"><title>%s</title></circle>`+"\n",
+			x, y, radius, hex(theme.colour(hold)), maxInt(b.cell/12, 1), hold.String())
+		if options.Labels {
+			fmt.Fprintf(&buffer, `<text x="%d" y="%d" font-size="%d" fill="%s">%s</text>`+"\n",
+				x+radius, y-radius, font*2/3, hex(theme.colour(hold)), hold.String())
+		}
+	}
+
+	fmt.Fprintln(&buffer, `</svg>`)
+	_, err := buffer.WriteTo(w)
+	return err
+}
+
+// hex returns the colour as an SVG hex colour, e.g. #00ff00.
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,61 @@
+package render
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSVG(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := SVG(&buffer, testProblem(), DefaultOptions); err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+	svg := buffer.String()
+
+	decoder := xml.NewDecoder(strings.NewReader(svg))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Errorf("Expected valid XML, got %s", err.Error())
+			t.FailNow()
+		}
+	}
+
+	for _, expected := range []string{
+		`width="500" height="860"`,
+		`Fat &lt;Boy&gt; 7A by Ben`,
+		`MoonBoard Masters 2017`,
+		`<circle cx="260" cy="640" r="16" fill="none" stroke="#00b000" stroke-width="3"><title>F5</title></circle>`,
+		`<circle cx="460" cy="120" r="16" fill="none" stroke="#e00000" stroke-width="3"><title>K18</title></circle>`,
+		`stroke="#0055ff"`,
+		`>G10</text>`,
+	} {
+		if !strings.Contains(svg, expected) {
+			t.Errorf("Expected the SVG to contain %s", expected)
+		}
+	}
+}
+
+func TestSVGOptions(t *testing.T) {
+	var buffer bytes.Buffer
+	options := Options{CellSize: 20, Theme: DarkTheme}
+	if err := SVG(&buffer, testProblem(), options); err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+	svg := buffer.String()
+
+	if !strings.Contains(svg, `width="250" height="400"`) || !strings.Contains(svg, `fill="#111111"`) {
+		t.Errorf("Expected a small dark board")
+	}
+	if strings.Contains(svg, "Fat") || strings.Contains(svg, ">G10</text>") {
+		t.Errorf("Expected no header or labels")
+	}
+}
@@ -0,0 +1,109 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/holds"
+	"github.com/cstdev/moonapi/query"
+)
+
+// ANSI escape codes used to colour holds in the terminal.
+const (
+	ansiGreen = "\x1b[32m"
+	ansiBlue  = "\x1b[34m"
+	ansiRed   = "\x1b[31m"
+	ansiReset = "\x1b[0m"
+)
+
+// Glyphs are the characters used to mark holds in text.
+type Glyphs struct {
+	Empty string
+	Start string
+	Move  string
+	End   string
+}
+
+// ASCIIGlyphs work in any terminal.
+var ASCIIGlyphs = Glyphs{Empty: ".", Start: "S", Move: "o", End: "F"}
+
+// UnicodeGlyphs are easier to tell apart in terminals that support them.
+var UnicodeGlyphs = Glyphs{Empty: "·", Start: "▲", Move: "●", End: "■"}
+
+// TextOptions changes how a problem is written as text.
+// Colour adds ANSI colours, green to start, blue for moves and red to
+// finish. Setup picks the size of the board, if empty it is read from the
+// problem.
+type TextOptions struct {
+	Glyphs Glyphs
+	Colour bool
+	Header bool
+	Setup  query.Setup
+}
+
+// DefaultTextOptions writes the board in plain ASCII with a header.
+var DefaultTextOptions = TextOptions{Glyphs: ASCIIGlyphs, Header: true}
+
+// Text writes the problem as a grid of characters with the column letters
+// above and below and the row numbers either side, top row first.
+func Text(w io.Writer, problem moonapi.Problem, options TextOptions) error {
+	b := layout(problem, Options{Setup: options.Setup})
+	glyphs := options.Glyphs
+	if glyphs == (Glyphs{}) {
+		glyphs = ASCIIGlyphs
+	}
+
+	marks := map[holds.Position]holds.Hold{}
+	for _, hold := range b.holdsOf(problem) {
+		marks[hold.Position] = hold
+	}
+
+	out := bufio.NewWriter(w)
+	if options.Header {
+		fmt.Fprintln(out, title(problem))
+		if problem.Holdsetup.Description != "" {
+			fmt.Fprintln(out, problem.Holdsetup.Description)
+		}
+		fmt.Fprintln(out)
+	}
+
+	columns := "   "
+	for column := 0; column < holds.Columns; column++ {
+		columns += " " + string(rune('A'+column))
+	}
+	fmt.Fprintln(out, columns)
+
+	for row := b.rows; row >= 1; row-- {
+		fmt.Fprintf(out, "%3d", row)
+		for column := 0; column < holds.Columns; column++ {
+			hold, ok := marks[holds.Position{Column: column, Row: row}]
+			fmt.Fprint(out, " "+glyph(hold, ok, glyphs, options.Colour))
+		}
+		fmt.Fprintf(out, " %d\n", row)
+	}
+
+	fmt.Fprintln(out, columns)
+	return out.Flush()
+}
+
+// glyph returns the mark for a position, coloured if asked for.
+func glyph(hold holds.Hold, used bool, glyphs Glyphs, colour bool) string {
+	if !used {
+		return glyphs.Empty
+	}
+
+	mark, code := glyphs.Move, ansiBlue
+	switch {
+	case hold.IsStart:
+		mark, code = glyphs.Start, ansiGreen
+	case hold.IsEnd:
+		mark, code = glyphs.End, ansiRed
+	}
+
+	if colour {
+		return code + mark + ansiReset
+	}
+	return mark
+}
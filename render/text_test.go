@@ -0,0 +1,57 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cstdev/moonapi/holds"
+	"github.com/cstdev/moonapi/query"
+)
+
+func TestText(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := Text(&buffer, testProblem(), DefaultTextOptions); err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+	lines := strings.Split(buffer.String(), "\n")
+
+	expected := map[int]string{
+		0:  "Fat <Boy> 7A by Ben",
+		1:  "MoonBoard Masters 2017",
+		3:  "    A B C D E F G H I J K",
+		4:  " 18 . . . . . . . . . . F 18",
+		12: " 10 . . . . . . o . . . . 10",
+		17: "  5 . . . . . S . . . . . 5",
+		21: "  1 . . . . . . . . . . . 1",
+		22: "    A B C D E F G H I J K",
+	}
+	for i, line := range expected {
+		if lines[i] != line {
+			t.Errorf("Expected line %d to be '%s', got '%s'", i, line, lines[i])
+		}
+	}
+}
+
+func TestTextMiniBoard(t *testing.T) {
+	var buffer bytes.Buffer
+	Text(&buffer, testProblem(), TextOptions{Setup: query.Mini2020})
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+
+	if len(lines) != holds.MiniRows+2 || !strings.HasPrefix(lines[1], " 12 ") {
+		t.Errorf("Expected 12 rows without a header, got %s", buffer.String())
+	}
+}
+
+func TestTextColourAndGlyphs(t *testing.T) {
+	var buffer bytes.Buffer
+	Text(&buffer, testProblem(), TextOptions{Glyphs: UnicodeGlyphs, Colour: true})
+	text := buffer.String()
+
+	for _, expected := range []string{"\x1b[32m▲\x1b[0m", "\x1b[34m●\x1b[0m", "\x1b[31m■\x1b[0m", "·"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected the board to contain %q", expected)
+		}
+	}
+}
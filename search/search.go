@@ -0,0 +1,272 @@
+// Package search finds problems by name and setter, tolerating accents,
+// typos and partly typed words, and ranks them by relevance.
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/cstdev/moonapi"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Field weights, a match in the problem name counts for more than a match
+// in the setter's name.
+const (
+	nameWeight   = 2.0
+	setterWeight = 1.0
+)
+
+// Scores given to a match depending on how the indexed word was matched.
+const (
+	exactMatch  = 1.0
+	prefixMatch = 0.8
+	fuzzyMatch  = 0.6
+)
+
+// minPrefixLength is the shortest query word that will match the start of
+// longer words.
+const minPrefixLength = 3
+
+// Result is a problem matching a search, with its relevance score and the
+// indexed words that matched.
+type Result struct {
+	Problem moonapi.Problem
+	Score   float64
+	Matches []string
+}
+
+// Options changes how a search is carried out.
+// MaxEdits is the number of typos allowed per word, a negative value
+// allows more typos in longer words. Limit is the most results returned,
+// 0 returns all of them.
+type Options struct {
+	MaxEdits int
+	Limit    int
+}
+
+// DefaultOptions allows typos based on the length of each word and returns
+// every result.
+var DefaultOptions = Options{MaxEdits: -1}
+
+type posting struct {
+	doc    int
+	weight float64
+}
+
+// Index is a search index over problem names and setter names.
+type Index struct {
+	problems []moonapi.Problem
+	postings map[string][]posting
+}
+
+// NewIndex indexes the name and setter of every problem passed in.
+func NewIndex(problems []moonapi.Problem) *Index {
+	idx := &Index{
+		problems: problems,
+		postings: map[string][]posting{},
+	}
+
+	for doc, problem := range problems {
+		weights := map[string]float64{}
+		addTokens(weights, problem.Name, nameWeight)
+		addTokens(weights, problem.Setter.Nickname, setterWeight)
+		addTokens(weights, problem.Setter.Firstname, setterWeight)
+		addTokens(weights, problem.Setter.Lastname, setterWeight)
+
+		for term, weight := range weights {
+			idx.postings[term] = append(idx.postings[term], posting{doc: doc, weight: weight})
+		}
+	}
+	return idx
+}
+
+// addTokens records the highest weight each word of text appears with.
+func addTokens(weights map[string]float64, text string, weight float64) {
+	for _, token := range Tokenize(text) {
+		if weights[token] < weight {
+			weights[token] = weight
+		}
+	}
+}
+
+// Search returns the problems matching text using DefaultOptions.
+func (idx *Index) Search(text string) []Result {
+	return idx.SearchWithOptions(text, DefaultOptions)
+}
+
+// SearchWithOptions returns the problems matching any word of text, most
+// relevant first. Problems matching more of the words, rarer words and
+// words in the problem name rank higher.
+func (idx *Index) SearchWithOptions(text string, options Options) []Result {
+	tokens := Tokenize(text)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	scores := map[int]float64{}
+	matched := map[int]int{}
+	matches := map[int][]string{}
+
+	for _, token := range tokens {
+		best := map[int]float64{}
+		bestTerm := map[int]string{}
+
+		for term, postings := range idx.postings {
+			similarity := match(token, term, options.MaxEdits)
+			if similarity == 0 {
+				continue
+			}
+
+			idf := math.Log(1 + float64(len(idx.problems))/float64(len(postings)))
+			for _, p := range postings {
+				score := similarity * idf * p.weight
+				if score > best[p.doc] {
+					best[p.doc] = score
+					bestTerm[p.doc] = term
+				}
+			}
+		}
+
+		for doc, score := range best {
+			scores[doc] += score
+			matched[doc]++
+			matches[doc] = append(matches[doc], bestTerm[doc])
+		}
+	}
+
+	var results []Result
+	for doc, score := range scores {
+		coverage := float64(matched[doc]) / float64(len(tokens))
+		results = append(results, Result{
+			Problem: idx.problems[doc],
+			Score:   score * coverage,
+			Matches: matches[doc],
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Problem.Name < results[j].Problem.Name
+	})
+
+	if options.Limit > 0 && len(results) > options.Limit {
+		results = results[:options.Limit]
+	}
+	return results
+}
+
+// match scores how well a query word matches an indexed word, 0 if it
+// doesn't match at all.
+func match(token string, term string, maxEdits int) float64 {
+	if token == term {
+		return exactMatch
+	}
+
+	if len([]rune(token)) >= minPrefixLength && strings.HasPrefix(term, token) {
+		return prefixMatch
+	}
+
+	if maxEdits < 0 {
+		maxEdits = allowedEdits(token)
+	}
+	if maxEdits == 0 {
+		return 0
+	}
+
+	distance := Distance(token, term, maxEdits)
+	if distance > maxEdits {
+		return 0
+	}
+	return fuzzyMatch * (1 - float64(distance)/float64(maxEdits+1))
+}
+
+// allowedEdits returns the number of typos allowed in a word of its length.
+func allowedEdits(token string) int {
+	switch length := len([]rune(token)); {
+	case length <= 3:
+		return 0
+	case length <= 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Distance returns the number of single character insertions, deletions,
+// substitutions and transpositions needed to turn a into b. Once the
+// distance is known to be above max, max+1 is returned.
+func Distance(a string, b string, max int) int {
+	ra, rb := []rune(a), []rune(b)
+	if diff := len(ra) - len(rb); diff > max || -diff > max {
+		return max + 1
+	}
+
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(minInt(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = minInt(curr[j], prev2[j-2]+1)
+			}
+			if curr[j] < rowMin {
+				rowMin = curr[j]
+			}
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	if prev[len(rb)] > max {
+		return max + 1
+	}
+	return prev[len(rb)]
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// foldings covers letters that don't decompose into a base letter and an
+// accent.
+var foldings = strings.NewReplacer("ß", "ss", "æ", "ae", "œ", "oe", "ø", "o", "ł", "l", "đ", "d", "þ", "th")
+
+// Fold lower cases text and removes accents, so é matches e and ü matches u.
+func Fold(text string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, strings.ToLower(text))
+	if err != nil {
+		folded = strings.ToLower(text)
+	}
+	return foldings.Replace(folded)
+}
+
+// Tokenize folds text and splits it into words of letters and numbers.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(Fold(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
@@ -0,0 +1,122 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/cstdev/moonapi"
+)
+
+func testProblems() []moonapi.Problem {
+	problems := []moonapi.Problem{
+		{ID: 1, Name: "Père Noël"},
+		{ID: 2, Name: "Crimpy Traverse"},
+		{ID: 3, Name: "Über Crimp"},
+		{ID: 4, Name: "Slopey Mantle"},
+		{ID: 5, Name: "Jump Start"},
+	}
+	problems[1].Setter.Nickname = "Ben"
+	problems[3].Setter.Nickname = "Crimpmaster"
+	problems[4].Setter.Firstname = "José"
+	problems[4].Setter.Lastname = "Müller"
+	return problems
+}
+
+func ids(results []Result) []int {
+	var out []int
+	for _, result := range results {
+		out = append(out, result.Problem.ID)
+	}
+	return out
+}
+
+func TestTokenizeFoldsAccents(t *testing.T) {
+	tokens := Tokenize("Père-Noël, Straße 7B+")
+	expected := []string{"pere", "noel", "strasse", "7b"}
+
+	if len(tokens) != len(expected) {
+		t.Errorf("Expected %v, got %v", expected, tokens)
+		t.FailNow()
+	}
+	for i := range expected {
+		if tokens[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, tokens)
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	cases := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{"crimp", "crimp", 0},
+		{"crimp", "crmip", 1},
+		{"crimp", "cramp", 1},
+		{"crimp", "crimpy", 1},
+		{"crimp", "slopey", 3},
+	}
+
+	for _, c := range cases {
+		if d := Distance(c.a, c.b, 2); d != c.expected {
+			t.Errorf("Expected distance from %s to %s to be %d, got %d", c.a, c.b, c.expected, d)
+		}
+	}
+}
+
+func TestSearchMatchesWithoutAccents(t *testing.T) {
+	results := NewIndex(testProblems()).Search("pere noel")
+
+	if len(results) != 1 || results[0].Problem.ID != 1 {
+		t.Errorf("Expected Père Noël, got %v", ids(results))
+	}
+}
+
+func TestSearchMatchesSetters(t *testing.T) {
+	results := NewIndex(testProblems()).Search("jose muller")
+
+	if len(results) != 1 || results[0].Problem.ID != 5 {
+		t.Errorf("Expected the problem set by José Müller, got %v", ids(results))
+	}
+}
+
+func TestSearchToleratesTypos(t *testing.T) {
+	results := NewIndex(testProblems()).Search("slpoey")
+
+	if len(results) != 1 || results[0].Problem.ID != 4 {
+		t.Errorf("Expected Slopey Mantle, got %v", ids(results))
+	}
+}
+
+func TestSearchRanksNamesAboveSetters(t *testing.T) {
+	results := NewIndex(testProblems()).Search("crimp")
+
+	got := ids(results)
+	if len(got) != 3 || got[0] != 3 || got[2] != 4 {
+		t.Errorf("Expected exact name match first and setter match last, got %v", got)
+	}
+}
+
+func TestSearchRanksProblemsMatchingMoreWords(t *testing.T) {
+	results := NewIndex(testProblems()).Search("crimp traverse")
+
+	if len(results) == 0 || results[0].Problem.ID != 2 {
+		t.Errorf("Expected Crimpy Traverse first, got %v", ids(results))
+	}
+}
+
+func TestSearchLimit(t *testing.T) {
+	results := NewIndex(testProblems()).SearchWithOptions("crimp", Options{MaxEdits: -1, Limit: 2})
+
+	if len(results) != 2 {
+		t.Errorf("Expected 2 results, got %d", len(results))
+	}
+}
+
+func TestSearchWithNoEdits(t *testing.T) {
+	results := NewIndex(testProblems()).SearchWithOptions("slpoey", Options{MaxEdits: 0})
+
+	if len(results) != 0 {
+		t.Errorf("Expected no results without typos allowed, got %v", ids(results))
+	}
+}
@@ -0,0 +1,54 @@
+package moonapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/headzoo/surf/browser"
+	"golang.org/x/net/publicsuffix"
+	"gopkg.in/headzoo/surf.v1"
+)
+
+// browser returns a browser holding the session's AuthTokens as cookies.
+// errors if the _MoonBoard AuthToken is missing
+func (m MoonBoard) browser() (*browser.Browser, error) {
+	containsAuth := false
+	for _, token := range m.auth {
+		if token.Name == "_MoonBoard" {
+			containsAuth = true
+			break
+		}
+	}
+	if !containsAuth {
+		return nil, errors.New("Required _Moonboard or __RequestVerificationToken Auth Tokens missing")
+	}
+
+	var cookies []*http.Cookie
+	for _, token := range m.auth {
+		cookies = append(cookies, tokenToCookie(token))
+	}
+
+	jar, _ := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+	u, _ := url.Parse(baseUrl)
+	jar.SetCookies(u, cookies)
+
+	bow := surf.NewBrowser()
+	bow.SetCookieJar(jar)
+	return bow, nil
+}
+
+// checkResponse errors if the browser was sent to the login page, as the
+// session has expired, or the server returned an error status.
+func checkResponse(bow *browser.Browser) error {
+	if strings.Contains(bow.Url().String(), "/"+loginUrl) {
+		return errors.New("session expired, please log in")
+	}
+	if bow.StatusCode() != 200 {
+		return errors.New("Server returned error status: " + strconv.Itoa(bow.StatusCode()))
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+// Package similarity compares problems by the holds they use, to find
+// problems like a given one and problems that copy another.
+package similarity
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/holds"
+)
+
+// Weights sets how much a hold counts towards similarity by how it is used.
+// A hold used as a start in one problem and an intermediate in another
+// counts for the smaller of the two weights.
+type Weights struct {
+	Start        float64
+	Intermediate float64
+	End          float64
+}
+
+// DefaultWeights counts start and finishing holds twice as much as the
+// holds in between.
+var DefaultWeights = Weights{Start: 2, Intermediate: 1, End: 2}
+
+// Match is a problem found to be similar to another.
+// Score is from 0 for no holds in common to 1 for the same holds used the
+// same way. Duplicate is true if Score is 1 and the problems have different
+// names, see IsDuplicate.
+type Match struct {
+	Problem   moonapi.Problem
+	Score     float64
+	Duplicate bool
+}
+
+// Engine finds similar problems among a set of problems.
+type Engine struct {
+	problems []moonapi.Problem
+	weights  Weights
+	holds    []map[holds.Position]float64
+}
+
+// New creates an Engine comparing against the problems passed in using
+// DefaultWeights.
+func New(problems []moonapi.Problem) *Engine {
+	return NewWithWeights(problems, DefaultWeights)
+}
+
+// NewWithWeights creates an Engine comparing against the problems passed in.
+func NewWithWeights(problems []moonapi.Problem, weights Weights) *Engine {
+	e := &Engine{problems: problems, weights: weights}
+	for _, problem := range problems {
+		e.holds = append(e.holds, weighted(problem, weights))
+	}
+	return e
+}
+
+// Similar returns the n problems most like the given problem, most similar
+// first. The problem itself, problems on another hold setup and problems
+// with no holds in common are left out. n of 0 returns every match.
+func (e *Engine) Similar(problem moonapi.Problem, n int) []Match {
+	target := weighted(problem, e.weights)
+
+	var matches []Match
+	for i, candidate := range e.problems {
+		if candidate.ID == problem.ID || !sameSetup(problem, candidate) {
+			continue
+		}
+
+		score := score(target, e.holds[i])
+		if score == 0 {
+			continue
+		}
+		matches = append(matches, Match{Problem: candidate, Score: score, Duplicate: score == 1 && !sameName(problem, candidate)})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Problem.Repeats > matches[j].Problem.Repeats
+	})
+
+	if n > 0 && len(matches) > n {
+		matches = matches[:n]
+	}
+	return matches
+}
+
+// Duplicates groups problems using exactly the same holds, in the same way,
+// on the same hold setup under different names. Only groups of more than
+// one problem are returned. Benchmarks come first in each group as they are
+// most likely the original, followed by the oldest.
+// Problems sharing a name are the same problem listed again rather than a
+// copy, so only the first of them is kept in a group.
+func (e *Engine) Duplicates() [][]moonapi.Problem {
+	groups := map[string][]moonapi.Problem{}
+	var keys []string
+	for _, problem := range e.problems {
+		key := signature(problem)
+		if key == "" {
+			continue
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], problem)
+	}
+
+	var duplicates [][]moonapi.Problem
+	for _, key := range keys {
+		group := groups[key]
+		sort.SliceStable(group, func(i, j int) bool {
+			if group[i].IsBenchmark != group[j].IsBenchmark {
+				return group[i].IsBenchmark
+			}
+			return older(group[i], group[j])
+		})
+
+		var named []moonapi.Problem
+		for _, problem := range group {
+			seen := false
+			for _, kept := range named {
+				if sameName(kept, problem) {
+					seen = true
+					break
+				}
+			}
+			if !seen {
+				named = append(named, problem)
+			}
+		}
+
+		if len(named) > 1 {
+			duplicates = append(duplicates, named)
+		}
+	}
+	return duplicates
+}
+
+// Score compares the holds of two problems using DefaultWeights, returning
+// 0 for no holds in common up to 1 for the same holds used the same way.
+func Score(a moonapi.Problem, b moonapi.Problem) float64 {
+	return ScoreWithWeights(a, b, DefaultWeights)
+}
+
+// ScoreWithWeights compares the holds of two problems, giving each hold the
+// weight of how it is used. It is the weighted Jaccard index of the holds:
+// the sum of the smaller weight of each hold over the sum of the larger.
+func ScoreWithWeights(a moonapi.Problem, b moonapi.Problem, weights Weights) float64 {
+	return score(weighted(a, weights), weighted(b, weights))
+}
+
+// Jaccard returns the number of holds two problems share over the number
+// of holds used by either, ignoring how they are used.
+func Jaccard(a moonapi.Problem, b moonapi.Problem) float64 {
+	same := Weights{Start: 1, Intermediate: 1, End: 1}
+	return score(weighted(a, same), weighted(b, same))
+}
+
+// IsDuplicate returns true if two problems with different names use exactly
+// the same holds, in the same way, on the same hold setup.
+func IsDuplicate(a moonapi.Problem, b moonapi.Problem) bool {
+	key := signature(a)
+	return a.ID != b.ID && !sameName(a, b) && key != "" && key == signature(b)
+}
+
+func score(a map[holds.Position]float64, b map[holds.Position]float64) float64 {
+	var min, max float64
+	for position, weightA := range a {
+		weightB := b[position]
+		if weightA < weightB {
+			min += weightA
+			max += weightB
+		} else {
+			min += weightB
+			max += weightA
+		}
+	}
+	for position, weightB := range b {
+		if _, ok := a[position]; !ok {
+			max += weightB
+		}
+	}
+
+	if max == 0 {
+		return 0
+	}
+	return min / max
+}
+
+// weighted gives each hold a problem uses the weight of how it's used. A
+// hold that is both a start and a finish takes the larger weight.
+func weighted(problem moonapi.Problem, weights Weights) map[holds.Position]float64 {
+	out := map[holds.Position]float64{}
+	for _, hold := range holds.Of(problem) {
+		weight := weights.Intermediate
+		if hold.IsStart && weights.Start > weight {
+			weight = weights.Start
+		}
+		if hold.IsEnd && weights.End > weight {
+			weight = weights.End
+		}
+		if weight > out[hold.Position] {
+			out[hold.Position] = weight
+		}
+	}
+	return out
+}
+
+// signature describes the holds of a problem and how they are used so that
+// problems with the same signature are duplicates.
+func signature(problem moonapi.Problem) string {
+	problemHolds := holds.Of(problem)
+	if len(problemHolds) == 0 {
+		return ""
+	}
+
+	var parts []string
+	for _, hold := range problemHolds {
+		part := hold.String()
+		if hold.IsStart {
+			part += "s"
+		}
+		if hold.IsEnd {
+			part += "e"
+		}
+		parts = append(parts, part)
+	}
+	sort.Strings(parts)
+	return problem.Holdsetup.Description + "|" + strings.Join(parts, ",")
+}
+
+// sameName returns true if the problems have the same name, ignoring case
+// and surrounding spaces.
+func sameName(a moonapi.Problem, b moonapi.Problem) bool {
+	return strings.EqualFold(strings.TrimSpace(a.Name), strings.TrimSpace(b.Name))
+}
+
+// older returns true if a was added to the website before b. Problems
+// without a date added are ordered by ID, which grows over time.
+func older(a moonapi.Problem, b moonapi.Problem) bool {
+	insertedA, errA := a.Inserted()
+	insertedB, errB := b.Inserted()
+	if errA == nil && errB == nil && !insertedA.IsZero() && !insertedB.IsZero() && !insertedA.Equal(insertedB) {
+		return insertedA.Before(insertedB)
+	}
+	return a.ID < b.ID
+}
+
+// sameSetup returns true unless both problems say which hold setup they are
+// on and the setups differ.
+func sameSetup(a moonapi.Problem, b moonapi.Problem) bool {
+	return a.Holdsetup.Description == "" || b.Holdsetup.Description == "" ||
+		a.Holdsetup.Description == b.Holdsetup.Description
+}
@@ -0,0 +1,171 @@
+package similarity
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/internal/problemtest"
+)
+
+func testProblem(id int, name string, start []string, middle []string, end []string) moonapi.Problem {
+	problem := problemtest.Problem(id, start, middle, end)
+	problem.Name = name
+	problem.Holdsetup.Description = "MoonBoard Masters 2017"
+	return problem
+}
+
+func testProblems() []moonapi.Problem {
+	benchmark := testProblem(1, "The Benchmark", []string{"F5"}, []string{"G8", "H11", "E14"}, []string{"F18"})
+	benchmark.IsBenchmark = true
+
+	return []moonapi.Problem{
+		benchmark,
+		testProblem(2, "Copy Cat", []string{"F5"}, []string{"E14", "G8", "H11"}, []string{"F18"}),
+		testProblem(3, "Close", []string{"F5"}, []string{"G8", "H11", "D13"}, []string{"F18"}),
+		testProblem(4, "Different Finish", []string{"F5"}, []string{"G8", "H11", "E14"}, []string{"A18"}),
+		testProblem(5, "Unrelated", []string{"A5"}, []string{"B8"}, []string{"K18"}),
+	}
+}
+
+func almostEqual(a float64, b float64) bool {
+	return math.Abs(a-b) < 0.0001
+}
+
+func TestScore(t *testing.T) {
+	problems := testProblems()
+
+	if score := Score(problems[0], problems[1]); score != 1 {
+		t.Errorf("Expected identical holds to score 1, got %f", score)
+	}
+
+	// F5 2, G8 1, H11 1, F18 2 shared out of E14 1 and D13 1 as well.
+	if score := Score(problems[0], problems[2]); !almostEqual(score, 6.0/8.0) {
+		t.Errorf("Expected a score of 0.75, got %f", score)
+	}
+
+	if score := Score(problems[0], problems[4]); score != 0 {
+		t.Errorf("Expected no holds in common to score 0, got %f", score)
+	}
+}
+
+func TestScoreWeightsFinish(t *testing.T) {
+	problems := testProblems()
+
+	changedMiddle := Score(problems[0], problems[2])
+	changedFinish := Score(problems[0], problems[3])
+	if changedFinish >= changedMiddle {
+		t.Errorf("Expected a different finish to count for more than a different intermediate, got %f and %f", changedFinish, changedMiddle)
+	}
+}
+
+func TestScorePartlyMatchesHoldsUsedDifferently(t *testing.T) {
+	a := testProblem(1, "A", []string{"F5"}, nil, []string{"F18"})
+	b := testProblem(2, "B", nil, []string{"F5"}, []string{"F18"})
+
+	// F5 counts 1 of 2, F18 counts 2 of 2.
+	if score := Score(a, b); !almostEqual(score, 3.0/4.0) {
+		t.Errorf("Expected a score of 0.75, got %f", score)
+	}
+
+	if score := Jaccard(a, b); score != 1 {
+		t.Errorf("Expected Jaccard to ignore how holds are used, got %f", score)
+	}
+}
+
+func TestSimilar(t *testing.T) {
+	problems := testProblems()
+	matches := New(problems).Similar(problems[0], 2)
+
+	if len(matches) != 2 {
+		t.Errorf("Expected 2 matches, got %d", len(matches))
+		t.FailNow()
+	}
+
+	if matches[0].Problem.ID != 2 || !matches[0].Duplicate {
+		t.Errorf("Expected the duplicate first, got %+v", matches[0])
+	}
+
+	if matches[1].Problem.ID != 3 || matches[1].Duplicate {
+		t.Errorf("Expected problem 3 second and not a duplicate, got %+v", matches[1])
+	}
+}
+
+func TestSimilarSkipsOtherSetups(t *testing.T) {
+	problems := testProblems()
+	problems[1].Holdsetup.Description = "MoonBoard 2016"
+
+	for _, match := range New(problems).Similar(problems[0], 0) {
+		if match.Problem.ID == 2 {
+			t.Errorf("Expected problems on other hold setups to be skipped")
+		}
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	problems := testProblems()
+	groups := New([]moonapi.Problem{problems[1], problems[2], problems[0]}).Duplicates()
+
+	if len(groups) != 1 || len(groups[0]) != 2 {
+		t.Errorf("Expected one pair of duplicates, got %v", groups)
+		t.FailNow()
+	}
+
+	if groups[0][0].ID != 1 || groups[0][1].ID != 2 {
+		t.Errorf("Expected the benchmark first, got %d and %d", groups[0][0].ID, groups[0][1].ID)
+	}
+}
+
+func TestIsDuplicate(t *testing.T) {
+	problems := testProblems()
+
+	if !IsDuplicate(problems[0], problems[1]) {
+		t.Errorf("Expected problems 1 and 2 to be duplicates")
+	}
+	if IsDuplicate(problems[0], problems[0]) {
+		t.Errorf("Expected a problem not to be a duplicate of itself")
+	}
+	if IsDuplicate(problems[0], problems[3]) {
+		t.Errorf("Expected problems with different finishes not to be duplicates")
+	}
+}
+
+func TestDuplicatesOrdersByDateAdded(t *testing.T) {
+	problems := testProblems()
+	newer := problems[1]
+	newer.DateInserted = "/Date(1524237090000)/"
+	older := testProblem(6, "Older Copy", []string{"F5"}, []string{"G8", "H11", "E14"}, []string{"F18"})
+	older.DateInserted = "/Date(1524237000000)/"
+
+	groups := New([]moonapi.Problem{newer, older, problems[0]}).Duplicates()
+	if len(groups) != 1 || len(groups[0]) != 3 {
+		t.Errorf("Expected one group of 3, got %v", groups)
+		t.FailNow()
+	}
+
+	if groups[0][0].ID != 1 || groups[0][1].ID != 6 || groups[0][2].ID != 2 {
+		t.Errorf("Expected the benchmark then the oldest, got %d, %d and %d", groups[0][0].ID, groups[0][1].ID, groups[0][2].ID)
+	}
+}
+
+func TestDuplicatesIgnoresProblemsWithTheSameName(t *testing.T) {
+	problems := testProblems()
+	relisted := problems[0]
+	relisted.ID = 6
+	relisted.IsBenchmark = false
+	relisted.Name = " the benchmark"
+
+	groups := New([]moonapi.Problem{problems[0], relisted}).Duplicates()
+	if len(groups) != 0 {
+		t.Errorf("Expected no duplicates for problems with the same name, got %v", groups)
+	}
+
+	if IsDuplicate(problems[0], relisted) {
+		t.Errorf("Expected problems with the same name not to be duplicates")
+	}
+
+	matches := New([]moonapi.Problem{relisted}).Similar(problems[0], 0)
+	if len(matches) != 1 || matches[0].Score != 1 || matches[0].Duplicate {
+		t.Errorf("Expected an identical match not flagged as a duplicate, got %+v", matches)
+	}
+}
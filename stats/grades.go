@@ -0,0 +1,103 @@
+package stats
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/query"
+)
+
+// GradeCount summarises the problems at a grade. Problems counts problems
+// with the grade set by the setter and UserGraded those with it as their
+// user grade. The averages are over Problems.
+type GradeCount struct {
+	Grade          string  `json:"grade"`
+	Problems       int     `json:"problems"`
+	UserGraded     int     `json:"userGraded"`
+	Benchmarks     int     `json:"benchmarks"`
+	AverageRating  float64 `json:"averageRating"`
+	AverageRepeats float64 `json:"averageRepeats"`
+}
+
+// GradeReport is how a set of problems is spread across the grades.
+// Ungraded counts problems whose grade isn't known.
+type GradeReport struct {
+	Problems       int          `json:"problems"`
+	Benchmarks     int          `json:"benchmarks"`
+	BenchmarkRatio float64      `json:"benchmarkRatio"`
+	Ungraded       int          `json:"ungraded"`
+	Grades         []GradeCount `json:"grades"`
+}
+
+// Grades counts the problems at each grade, easiest first.
+func Grades(problems []moonapi.Problem) GradeReport {
+	report := GradeReport{Problems: len(problems)}
+
+	counts := make([]GradeCount, query.EightBPlus+1)
+	ratings := make([]int, len(counts))
+	repeats := make([]int, len(counts))
+	for grade := range counts {
+		counts[grade].Grade = query.Grade(grade).String()
+	}
+
+	for _, problem := range problems {
+		if problem.IsBenchmark {
+			report.Benchmarks++
+		}
+
+		if grade, ok := UserGrade(problem); ok {
+			counts[grade].UserGraded++
+		}
+
+		grade, ok := gradeOf(problem.Grade)
+		if !ok {
+			report.Ungraded++
+			continue
+		}
+		counts[grade].Problems++
+		ratings[grade] += problem.Rating
+		repeats[grade] += problem.Repeats
+		if problem.IsBenchmark {
+			counts[grade].Benchmarks++
+		}
+	}
+
+	for grade := range counts {
+		if n := counts[grade].Problems; n > 0 {
+			counts[grade].AverageRating = float64(ratings[grade]) / float64(n)
+			counts[grade].AverageRepeats = float64(repeats[grade]) / float64(n)
+		}
+	}
+	if report.Problems > 0 {
+		report.BenchmarkRatio = float64(report.Benchmarks) / float64(report.Problems)
+	}
+	report.Grades = counts
+	return report
+}
+
+// WriteTable writes the grades as a table aligned for the terminal.
+func (r GradeReport) WriteTable(w io.Writer) error {
+	out := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(out, "Grade\tProblems\tUser graded\tBenchmarks\tAvg rating\tAvg repeats\t")
+	for _, g := range r.Grades {
+		fmt.Fprintf(out, "%s\t%d\t%d\t%d\t%.2f\t%.1f\t\n", g.Grade, g.Problems, g.UserGraded, g.Benchmarks, g.AverageRating, g.AverageRepeats)
+	}
+	fmt.Fprintf(out, "\nProblems: %d, Benchmarks: %d (%.1f%%), Ungraded: %d\n", r.Problems, r.Benchmarks, r.BenchmarkRatio*100, r.Ungraded)
+	return out.Flush()
+}
+
+// WriteCSV writes a row for each grade.
+func (r GradeReport) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"grade", "problems", "userGraded", "benchmarks", "averageRating", "averageRepeats"})
+	for _, g := range r.Grades {
+		out.Write([]string{g.Grade, strconv.Itoa(g.Problems), strconv.Itoa(g.UserGraded), strconv.Itoa(g.Benchmarks),
+			strconv.FormatFloat(g.AverageRating, 'f', 2, 64), strconv.FormatFloat(g.AverageRepeats, 'f', 2, 64)})
+	}
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/query"
+)
+
+func gradedProblems() []moonapi.Problem {
+	return []moonapi.Problem{
+		{ID: 1, Grade: "6B+", UserGrade: "6B+", Rating: 3, Repeats: 100, IsBenchmark: true},
+		{ID: 2, Grade: "6B+", UserGrade: "6C", Rating: 1, Repeats: 20},
+		{ID: 3, Grade: "7A", UserGrade: nil, Rating: 2, Repeats: 5},
+		{ID: 4, Grade: "", Rating: 0, Repeats: 0},
+	}
+}
+
+func TestGrades(t *testing.T) {
+	report := Grades(gradedProblems())
+
+	if report.Problems != 4 || report.Benchmarks != 1 || report.BenchmarkRatio != 0.25 || report.Ungraded != 1 {
+		t.Errorf("Unexpected totals, got %+v", report)
+	}
+
+	if len(report.Grades) != int(query.EightBPlus)+1 {
+		t.Errorf("Expected a count for every grade, got %d", len(report.Grades))
+		t.FailNow()
+	}
+
+	sixBPlus := report.Grades[query.SixBPlus]
+	if sixBPlus.Grade != "6B+" || sixBPlus.Problems != 2 || sixBPlus.UserGraded != 1 || sixBPlus.Benchmarks != 1 ||
+		sixBPlus.AverageRating != 2 || sixBPlus.AverageRepeats != 60 {
+		t.Errorf("Unexpected 6B+ count, got %+v", sixBPlus)
+	}
+
+	if report.Grades[query.SixC].UserGraded != 1 || report.Grades[query.SixC].Problems != 0 {
+		t.Errorf("Expected one problem user graded 6C, got %+v", report.Grades[query.SixC])
+	}
+}
+
+func TestGradesCSV(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := Grades(gradedProblems()).WriteCSV(&buffer); err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	lines := strings.Split(buffer.String(), "\n")
+	if lines[0] != "grade,problems,userGraded,benchmarks,averageRating,averageRepeats" || lines[5] != "6B+,2,1,1,2.00,60.00" {
+		t.Errorf("Unexpected CSV, got %s", buffer.String())
+	}
+}
+
+func TestGradesTable(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := Grades(gradedProblems()).WriteTable(&buffer); err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if !strings.Contains(buffer.String(), "Problems: 4, Benchmarks: 1 (25.0%), Ungraded: 1") {
+		t.Errorf("Expected a summary line, got %s", buffer.String())
+	}
+}
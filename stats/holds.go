@@ -0,0 +1,194 @@
+// Package stats summarises sets of problems, such as how often each hold
+// is used and how problems are spread across grades and setters.
+package stats
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/holds"
+	"github.com/cstdev/moonapi/query"
+)
+
+// GradeBand is a named range of grades, both ends included.
+type GradeBand struct {
+	Name string
+	Min  query.Grade
+	Max  query.Grade
+}
+
+// DefaultBands splits the grades into four bands.
+var DefaultBands = []GradeBand{
+	{Name: "5+-6B+", Min: query.FivePlus, Max: query.SixBPlus},
+	{Name: "6C-7A+", Min: query.SixC, Max: query.SevenAPlus},
+	{Name: "7B-7C+", Min: query.SevenB, Max: query.SevenCPlus},
+	{Name: "8A-8B+", Min: query.EightA, Max: query.EightBPlus},
+}
+
+// HoldUsage counts the problems using a hold, split by how it is used and
+// by the grade band of the problem.
+type HoldUsage struct {
+	Hold         string         `json:"hold"`
+	Column       int            `json:"column"`
+	Row          int            `json:"row"`
+	Start        int            `json:"start"`
+	Intermediate int            `json:"intermediate"`
+	End          int            `json:"end"`
+	Total        int            `json:"total"`
+	ByGrade      map[string]int `json:"byGrade"`
+}
+
+// HoldReport is the usage of every hold across a set of problems.
+// Holds are the used holds, most used first. Unused are the positions on
+// the board no problem uses, including positions without a hold.
+type HoldReport struct {
+	Problems int         `json:"problems"`
+	Bands    []GradeBand `json:"-"`
+	Holds    []HoldUsage `json:"holds"`
+	Unused   []string    `json:"unused"`
+}
+
+// Holds counts how often each hold is used by the problems, splitting the
+// counts by the grade bands passed in. Problems with a grade outside every
+// band only count towards the totals.
+func Holds(problems []moonapi.Problem, bands []GradeBand) HoldReport {
+	report := HoldReport{Problems: len(problems), Bands: bands, Holds: []HoldUsage{}, Unused: []string{}}
+
+	usage := map[holds.Position]*HoldUsage{}
+	for _, problem := range problems {
+		band := bandOf(problem, bands)
+
+		counted := map[holds.Position]bool{}
+		for _, hold := range holds.Of(problem) {
+			if counted[hold.Position] {
+				continue
+			}
+			counted[hold.Position] = true
+
+			u, ok := usage[hold.Position]
+			if !ok {
+				u = &HoldUsage{Hold: hold.String(), Column: hold.Column, Row: hold.Row, ByGrade: map[string]int{}}
+				usage[hold.Position] = u
+			}
+
+			switch {
+			case hold.IsStart:
+				u.Start++
+			case hold.IsEnd:
+				u.End++
+			default:
+				u.Intermediate++
+			}
+			u.Total++
+			if band != "" {
+				u.ByGrade[band]++
+			}
+		}
+	}
+
+	for row := 1; row <= holds.Rows; row++ {
+		for column := 0; column < holds.Columns; column++ {
+			position := holds.Position{Column: column, Row: row}
+			if u, ok := usage[position]; ok {
+				report.Holds = append(report.Holds, *u)
+			} else {
+				report.Unused = append(report.Unused, position.String())
+			}
+		}
+	}
+
+	sort.SliceStable(report.Holds, func(i, j int) bool {
+		return report.Holds[i].Total > report.Holds[j].Total
+	})
+	return report
+}
+
+// Grid returns the total uses of each hold for drawing a heatmap, indexed
+// by row then column from the bottom left of the board, so Grid()[0][0] is
+// the count for A1.
+func (r HoldReport) Grid() [][]int {
+	grid := make([][]int, holds.Rows)
+	for row := range grid {
+		grid[row] = make([]int, holds.Columns)
+	}
+	for _, u := range r.Holds {
+		grid[u.Row-1][u.Column] = u.Total
+	}
+	return grid
+}
+
+// WriteTable writes the used holds as a table aligned for the terminal,
+// followed by the unused positions.
+func (r HoldReport) WriteTable(w io.Writer) error {
+	out := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(out, "Hold\tStart\tIntermediate\tEnd\tTotal\t")
+	for _, band := range r.Bands {
+		fmt.Fprintf(out, "%s\t", band.Name)
+	}
+	fmt.Fprintln(out)
+
+	for _, u := range r.Holds {
+		fmt.Fprintf(out, "%s\t%d\t%d\t%d\t%d\t", u.Hold, u.Start, u.Intermediate, u.End, u.Total)
+		for _, band := range r.Bands {
+			fmt.Fprintf(out, "%d\t", u.ByGrade[band.Name])
+		}
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintf(out, "\nUnused: %s\n", strings.Join(r.Unused, ", "))
+	return out.Flush()
+}
+
+// WriteCSV writes a row for each used hold with a column for each grade
+// band after the totals.
+func (r HoldReport) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+
+	header := []string{"hold", "column", "row", "start", "intermediate", "end", "total"}
+	for _, band := range r.Bands {
+		header = append(header, band.Name)
+	}
+	if err := out.Write(header); err != nil {
+		return err
+	}
+
+	for _, u := range r.Holds {
+		record := []string{u.Hold, strconv.Itoa(u.Column), strconv.Itoa(u.Row),
+			strconv.Itoa(u.Start), strconv.Itoa(u.Intermediate), strconv.Itoa(u.End), strconv.Itoa(u.Total)}
+		for _, band := range r.Bands {
+			record = append(record, strconv.Itoa(u.ByGrade[band.Name]))
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// bandOf returns the name of the band the problem's grade is in, or an
+// empty string if it is in none of them.
+func bandOf(problem moonapi.Problem, bands []GradeBand) string {
+	grade, ok := gradeOf(problem.Grade)
+	if !ok {
+		return ""
+	}
+	for _, band := range bands {
+		if grade >= band.Min && grade <= band.Max {
+			return band.Name
+		}
+	}
+	return ""
+}
+
+func gradeOf(s string) (query.Grade, bool) {
+	var grade query.Grade
+	err := grade.UnmarshalText([]byte(s))
+	return grade, err == nil
+}
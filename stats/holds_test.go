@@ -0,0 +1,96 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/holds"
+	"github.com/cstdev/moonapi/internal/problemtest"
+)
+
+func holdProblem(id int, grade string, start string, middle string, end string) moonapi.Problem {
+	problem := problemtest.Problem(id, []string{start}, []string{middle}, []string{end})
+	problem.Grade = grade
+	return problem
+}
+
+func holdProblems() []moonapi.Problem {
+	return []moonapi.Problem{
+		holdProblem(1, "6B", "F5", "G8", "F18"),
+		holdProblem(2, "7A", "F5", "K12", "E18"),
+		holdProblem(3, "7B+", "A5", "F5", "F18"),
+		holdProblem(4, "unknown", "F5", "G8", "F18"),
+	}
+}
+
+func TestHolds(t *testing.T) {
+	report := Holds(holdProblems(), DefaultBands)
+
+	if report.Problems != 4 {
+		t.Errorf("Expected 4 problems, got %d", report.Problems)
+	}
+
+	top := report.Holds[0]
+	if top.Hold != "F5" || top.Start != 3 || top.Intermediate != 1 || top.End != 0 || top.Total != 4 {
+		t.Errorf("Expected F5 to be used most, got %+v", top)
+	}
+
+	if top.ByGrade["5+-6B+"] != 1 || top.ByGrade["6C-7A+"] != 1 || top.ByGrade["7B-7C+"] != 1 || len(top.ByGrade) != 3 {
+		t.Errorf("Expected F5 to be split across three bands, got %v", top.ByGrade)
+	}
+
+	if len(report.Holds)+len(report.Unused) != holds.Rows*holds.Columns {
+		t.Errorf("Expected every position to be used or unused, got %d and %d", len(report.Holds), len(report.Unused))
+	}
+
+	for _, unused := range report.Unused {
+		if unused == "K12" {
+			t.Errorf("Expected K12 to be used")
+		}
+	}
+}
+
+func TestHoldsGrid(t *testing.T) {
+	grid := Holds(holdProblems(), DefaultBands).Grid()
+
+	if len(grid) != holds.Rows || len(grid[0]) != holds.Columns {
+		t.Errorf("Expected a %d by %d grid, got %d by %d", holds.Rows, holds.Columns, len(grid), len(grid[0]))
+		t.FailNow()
+	}
+
+	if grid[4][5] != 4 || grid[17][5] != 3 || grid[0][0] != 0 {
+		t.Errorf("Expected F5 4, F18 3 and A1 0, got %d, %d and %d", grid[4][5], grid[17][5], grid[0][0])
+	}
+}
+
+func TestHoldsCSV(t *testing.T) {
+	var buffer bytes.Buffer
+	err := Holds(holdProblems(), DefaultBands).WriteCSV(&buffer)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if lines[0] != "hold,column,row,start,intermediate,end,total,5+-6B+,6C-7A+,7B-7C+,8A-8B+" {
+		t.Errorf("Unexpected header, got %s", lines[0])
+	}
+	if lines[1] != "F5,5,5,3,1,0,4,1,1,1,0" {
+		t.Errorf("Unexpected first row, got %s", lines[1])
+	}
+}
+
+func TestHoldsJSON(t *testing.T) {
+	data, err := json.Marshal(Holds(holdProblems()[:1], DefaultBands))
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if !strings.Contains(string(data), `{"hold":"G8","column":6,"row":8,"start":0,"intermediate":1,"end":0,"total":1,"byGrade":{"5+-6B+":1}}`) {
+		t.Errorf("Unexpected JSON, got %s", string(data))
+	}
+}
@@ -0,0 +1,137 @@
+package stats
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cstdev/moonapi"
+)
+
+// SetterOrder is what a setter leaderboard is ranked by.
+type SetterOrder string
+
+const (
+	ByProblems SetterOrder = "problems"
+	ByRepeats  SetterOrder = "repeats"
+	ByRating   SetterOrder = "rating"
+)
+
+// ToSetterOrder converts a string to a SetterOrder.
+// errors if it isn't one of problems, repeats or rating
+func ToSetterOrder(order string) (SetterOrder, error) {
+	switch SetterOrder(strings.ToLower(order)) {
+	case ByProblems:
+		return ByProblems, nil
+	case ByRepeats:
+		return ByRepeats, nil
+	case ByRating:
+		return ByRating, nil
+	}
+	return "", errors.New("setter order must be one of problems, repeats or rating, got '" + order + "'")
+}
+
+// SetterStats summarises the problems set by one setter.
+type SetterStats struct {
+	Setter        string  `json:"setter"`
+	Problems      int     `json:"problems"`
+	Benchmarks    int     `json:"benchmarks"`
+	TotalRepeats  int     `json:"totalRepeats"`
+	AverageRating float64 `json:"averageRating"`
+}
+
+// SetterReport is a leaderboard of setters, best first.
+type SetterReport struct {
+	Order   SetterOrder   `json:"order"`
+	Setters []SetterStats `json:"setters"`
+}
+
+// Setters ranks the setters of the problems. Setters are told apart by
+// their Id, or their Nickname if they have no Id. Ties are broken by the
+// number of problems set, then by name.
+func Setters(problems []moonapi.Problem, order SetterOrder) SetterReport {
+	bySetter := map[string]*SetterStats{}
+	ratings := map[string]int{}
+	var keys []string
+
+	for _, problem := range problems {
+		key := problem.Setter.ID
+		if key == "" {
+			key = problem.Setter.Nickname
+		}
+
+		s, ok := bySetter[key]
+		if !ok {
+			s = &SetterStats{Setter: problem.Setter.Nickname}
+			bySetter[key] = s
+			keys = append(keys, key)
+		}
+		s.Problems++
+		s.TotalRepeats += problem.Repeats
+		ratings[key] += problem.Rating
+		if problem.IsBenchmark {
+			s.Benchmarks++
+		}
+	}
+
+	report := SetterReport{Order: order, Setters: []SetterStats{}}
+	for _, key := range keys {
+		s := bySetter[key]
+		s.AverageRating = float64(ratings[key]) / float64(s.Problems)
+		report.Setters = append(report.Setters, *s)
+	}
+
+	setters := report.Setters
+	sort.SliceStable(setters, func(i, j int) bool {
+		switch order {
+		case ByRepeats:
+			if setters[i].TotalRepeats != setters[j].TotalRepeats {
+				return setters[i].TotalRepeats > setters[j].TotalRepeats
+			}
+		case ByRating:
+			if setters[i].AverageRating != setters[j].AverageRating {
+				return setters[i].AverageRating > setters[j].AverageRating
+			}
+		}
+		if setters[i].Problems != setters[j].Problems {
+			return setters[i].Problems > setters[j].Problems
+		}
+		return strings.ToLower(setters[i].Setter) < strings.ToLower(setters[j].Setter)
+	})
+	return report
+}
+
+// Top returns the report with only the first n setters.
+func (r SetterReport) Top(n int) SetterReport {
+	if n > 0 && len(r.Setters) > n {
+		r.Setters = r.Setters[:n]
+	}
+	return r
+}
+
+// WriteTable writes the leaderboard as a table aligned for the terminal.
+func (r SetterReport) WriteTable(w io.Writer) error {
+	out := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(out, "#\tSetter\tProblems\tBenchmarks\tRepeats\tAvg rating")
+	for i, s := range r.Setters {
+		fmt.Fprintf(out, "%d\t%s\t%d\t%d\t%d\t%.2f\n", i+1, s.Setter, s.Problems, s.Benchmarks, s.TotalRepeats, s.AverageRating)
+	}
+	return out.Flush()
+}
+
+// WriteCSV writes a row for each setter.
+func (r SetterReport) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"rank", "setter", "problems", "benchmarks", "totalRepeats", "averageRating"})
+	for i, s := range r.Setters {
+		out.Write([]string{strconv.Itoa(i + 1), s.Setter, strconv.Itoa(s.Problems), strconv.Itoa(s.Benchmarks),
+			strconv.Itoa(s.TotalRepeats), strconv.FormatFloat(s.AverageRating, 'f', 2, 64)})
+	}
+	out.Flush()
+	return out.Error()
+}
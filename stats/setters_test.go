@@ -0,0 +1,97 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/internal/problemtest"
+)
+
+func setProblem(setterID string, nickname string, rating int, repeats int) moonapi.Problem {
+	problem := problemtest.Problem(0, nil, nil, nil)
+	problem.Rating = rating
+	problem.Repeats = repeats
+	problem.Setter.ID = setterID
+	problem.Setter.Nickname = nickname
+	return problem
+}
+
+func setProblems() []moonapi.Problem {
+	return []moonapi.Problem{
+		setProblem("1", "Ben", 1, 10),
+		setProblem("1", "Ben", 2, 20),
+		setProblem("1", "Ben", 3, 30),
+		setProblem("2", "Alex", 3, 500),
+		setProblem("", "Sam", 2, 5),
+		setProblem("", "Sam", 2, 5),
+	}
+}
+
+func names(report SetterReport) []string {
+	var out []string
+	for _, s := range report.Setters {
+		out = append(out, s.Setter)
+	}
+	return out
+}
+
+func TestSettersByProblems(t *testing.T) {
+	report := Setters(setProblems(), ByProblems)
+
+	if got := strings.Join(names(report), ","); got != "Ben,Sam,Alex" {
+		t.Errorf("Expected Ben,Sam,Alex, got %s", got)
+	}
+
+	ben := report.Setters[0]
+	if ben.Problems != 3 || ben.TotalRepeats != 60 || ben.AverageRating != 2 {
+		t.Errorf("Unexpected stats for Ben, got %+v", ben)
+	}
+}
+
+func TestSettersByRepeats(t *testing.T) {
+	report := Setters(setProblems(), ByRepeats)
+
+	if got := strings.Join(names(report), ","); got != "Alex,Ben,Sam" {
+		t.Errorf("Expected Alex,Ben,Sam, got %s", got)
+	}
+}
+
+func TestSettersByRating(t *testing.T) {
+	report := Setters(setProblems(), ByRating)
+
+	if got := strings.Join(names(report), ","); got != "Alex,Ben,Sam" {
+		t.Errorf("Expected Alex,Ben,Sam with Ben ahead of Sam on problems set, got %s", got)
+	}
+}
+
+func TestSettersTop(t *testing.T) {
+	report := Setters(setProblems(), ByProblems).Top(1)
+
+	if len(report.Setters) != 1 || report.Setters[0].Setter != "Ben" {
+		t.Errorf("Expected only Ben, got %v", names(report))
+	}
+}
+
+func TestSettersCSV(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := Setters(setProblems(), ByProblems).WriteCSV(&buffer); err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	lines := strings.Split(buffer.String(), "\n")
+	if lines[0] != "rank,setter,problems,benchmarks,totalRepeats,averageRating" || lines[1] != "1,Ben,3,0,60,2.00" {
+		t.Errorf("Unexpected CSV, got %s", buffer.String())
+	}
+}
+
+func TestToSetterOrder(t *testing.T) {
+	if order, err := ToSetterOrder("Repeats"); err != nil || order != ByRepeats {
+		t.Errorf("Expected repeats, got %s", order)
+	}
+	if _, err := ToSetterOrder("height"); err == nil {
+		t.Errorf("Expected an error for an unknown order")
+	}
+}
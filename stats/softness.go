@@ -0,0 +1,247 @@
+package stats
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/query"
+)
+
+// Verdict says whether the setter's grade agrees with the user grade.
+type Verdict string
+
+const (
+	// Soft problems are easier than the setter graded them.
+	Soft Verdict = "soft"
+	// Sandbagged problems are harder than the setter graded them.
+	Sandbagged Verdict = "sandbagged"
+	Fair       Verdict = "fair"
+)
+
+// SoftnessOptions changes when a problem is flagged.
+// Threshold is how many grades the user grade must differ from the
+// setter's grade by. Problems with fewer than MinRepeats repeats are
+// skipped, as their user grade is the opinion of too few climbers.
+type SoftnessOptions struct {
+	Threshold  int
+	MinRepeats int
+}
+
+// DefaultSoftnessOptions flags problems a grade or more out with at least
+// 5 repeats.
+var DefaultSoftnessOptions = SoftnessOptions{Threshold: 1, MinRepeats: 5}
+
+// ProblemGrade compares the setter's grade of a problem to its user grade.
+// Delta is the number of grades the user grade is above the setter's grade.
+type ProblemGrade struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Setter      string   `json:"setter"`
+	IsBenchmark bool     `json:"isBenchmark"`
+	Repeats     int      `json:"repeats"`
+	HoldSets    []string `json:"holdSets"`
+	Grade       string   `json:"grade"`
+	UserGrade   string   `json:"userGrade"`
+	Delta       int      `json:"delta"`
+	Verdict     Verdict  `json:"verdict"`
+}
+
+// GroupSoftness sums up the verdicts for the problems of a setter or hold
+// set. AverageDelta above 0 means the group tends to be sandbagged.
+type GroupSoftness struct {
+	Name         string  `json:"name"`
+	Problems     int     `json:"problems"`
+	Soft         int     `json:"soft"`
+	Sandbagged   int     `json:"sandbagged"`
+	Fair         int     `json:"fair"`
+	AverageDelta float64 `json:"averageDelta"`
+}
+
+// SoftnessReport compares setters' grades to user grades.
+// Skipped counts problems without both grades or with too few repeats.
+type SoftnessReport struct {
+	Options   SoftnessOptions `json:"options"`
+	Skipped   int             `json:"skipped"`
+	Problems  []ProblemGrade  `json:"problems"`
+	BySetter  []GroupSoftness `json:"bySetter"`
+	ByHoldSet []GroupSoftness `json:"byHoldSet"`
+}
+
+// Softness compares the grade of each problem to its user grade, flagging
+// soft and sandbagged problems and summing them up by setter and by hold
+// set. Problems count towards every hold set they use.
+// errors if Threshold is below 1 or MinRepeats is negative
+func Softness(problems []moonapi.Problem, options SoftnessOptions) (SoftnessReport, error) {
+	if options.Threshold < 1 || options.MinRepeats < 0 {
+		return SoftnessReport{}, errors.New("threshold must be at least 1 and min repeats cannot be negative")
+	}
+
+	report := SoftnessReport{Options: options, Problems: []ProblemGrade{}}
+	setters := newGroups()
+	holdSets := newGroups()
+
+	for _, problem := range problems {
+		grade, ok := gradeOf(problem.Grade)
+		if !ok {
+			report.Skipped++
+			continue
+		}
+		userGrade, ok := UserGrade(problem)
+		if !ok || problem.Repeats < options.MinRepeats {
+			report.Skipped++
+			continue
+		}
+
+		pg := ProblemGrade{
+			ID:          problem.ID,
+			Name:        problem.Name,
+			Setter:      problem.Setter.Nickname,
+			IsBenchmark: problem.IsBenchmark,
+			Repeats:     problem.Repeats,
+			HoldSets:    problem.HoldSetNames(),
+			Grade:       grade.String(),
+			UserGrade:   userGrade.String(),
+			Delta:       int(userGrade - grade),
+			Verdict:     Fair,
+		}
+		switch {
+		case pg.Delta <= -options.Threshold:
+			pg.Verdict = Soft
+		case pg.Delta >= options.Threshold:
+			pg.Verdict = Sandbagged
+		}
+		report.Problems = append(report.Problems, pg)
+
+		setters.add(pg.Setter, pg)
+		if len(pg.HoldSets) == 0 {
+			holdSets.add("unknown", pg)
+		}
+		for _, holdSet := range pg.HoldSets {
+			holdSets.add(holdSet, pg)
+		}
+	}
+
+	report.BySetter = setters.summary()
+	report.ByHoldSet = holdSets.summary()
+	return report, nil
+}
+
+// Honest returns the benchmarks whose grade agrees with their user grade,
+// most repeated first.
+func (r SoftnessReport) Honest() []ProblemGrade {
+	honest := []ProblemGrade{}
+	for _, pg := range r.Problems {
+		if pg.IsBenchmark && pg.Verdict == Fair {
+			honest = append(honest, pg)
+		}
+	}
+	sort.SliceStable(honest, func(i, j int) bool {
+		return honest[i].Repeats > honest[j].Repeats
+	})
+	return honest
+}
+
+// WriteTable writes the summaries by setter and by hold set as tables
+// aligned for the terminal.
+func (r SoftnessReport) WriteTable(w io.Writer) error {
+	out := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, section := range []struct {
+		title  string
+		groups []GroupSoftness
+	}{
+		{"Setter", r.BySetter},
+		{"Hold set", r.ByHoldSet},
+	} {
+		fmt.Fprintf(out, "%s\tProblems\tSoft\tSandbagged\tFair\tAvg delta\n", section.title)
+		for _, g := range section.groups {
+			fmt.Fprintf(out, "%s\t%d\t%d\t%d\t%d\t%+.2f\n", g.Name, g.Problems, g.Soft, g.Sandbagged, g.Fair, g.AverageDelta)
+		}
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintf(out, "Compared: %d, Skipped: %d\n", len(r.Problems), r.Skipped)
+	return out.Flush()
+}
+
+// WriteCSV writes a row for each problem compared.
+func (r SoftnessReport) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"id", "name", "setter", "benchmark", "repeats", "holdSets", "grade", "userGrade", "delta", "verdict"})
+	for _, pg := range r.Problems {
+		out.Write([]string{strconv.Itoa(pg.ID), pg.Name, pg.Setter, strconv.FormatBool(pg.IsBenchmark), strconv.Itoa(pg.Repeats),
+			strings.Join(pg.HoldSets, ";"), pg.Grade, pg.UserGrade, strconv.Itoa(pg.Delta), string(pg.Verdict)})
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// UserGrade decodes the grade the community has given a problem. The
+// website sends it as a grade such as "6B+", an object with a Grade or
+// Description, or nothing if no one has graded it yet.
+func UserGrade(problem moonapi.Problem) (query.Grade, bool) {
+	return decodeGrade(problem.UserGrade)
+}
+
+func decodeGrade(value interface{}) (query.Grade, bool) {
+	switch v := value.(type) {
+	case string:
+		return gradeOf(strings.TrimSpace(v))
+	case map[string]interface{}:
+		if grade, ok := decodeGrade(v["Grade"]); ok {
+			return grade, true
+		}
+		return decodeGrade(v["Description"])
+	}
+	return 0, false
+}
+
+// groups sums up verdicts by name, remembering the order names were seen.
+type groups struct {
+	names  []string
+	byName map[string]*GroupSoftness
+	deltas map[string]int
+}
+
+func newGroups() *groups {
+	return &groups{byName: map[string]*GroupSoftness{}, deltas: map[string]int{}}
+}
+
+func (g *groups) add(name string, pg ProblemGrade) {
+	group, ok := g.byName[name]
+	if !ok {
+		group = &GroupSoftness{Name: name}
+		g.byName[name] = group
+		g.names = append(g.names, name)
+	}
+
+	group.Problems++
+	g.deltas[name] += pg.Delta
+	switch pg.Verdict {
+	case Soft:
+		group.Soft++
+	case Sandbagged:
+		group.Sandbagged++
+	default:
+		group.Fair++
+	}
+}
+
+// summary returns the groups with the most problems first.
+func (g *groups) summary() []GroupSoftness {
+	out := []GroupSoftness{}
+	for _, name := range g.names {
+		group := *g.byName[name]
+		group.AverageDelta = float64(g.deltas[name]) / float64(group.Problems)
+		out = append(out, group)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Problems > out[j].Problems
+	})
+	return out
+}
@@ -0,0 +1,150 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/internal/problemtest"
+	"github.com/cstdev/moonapi/query"
+)
+
+func softProblem(id int, setter string, holdSet string, grade string, userGrade interface{}, repeats int, benchmark bool) moonapi.Problem {
+	problem := problemtest.Problem(id, nil, nil, nil)
+	problem.Grade = grade
+	problem.UserGrade = userGrade
+	problem.Repeats = repeats
+	problem.IsBenchmark = benchmark
+	problem.Setter.Nickname = setter
+	if holdSet != "" {
+		problem.Holdsets = problemtest.HoldSets(holdSet)
+	}
+	return problem
+}
+
+func softProblems() []moonapi.Problem {
+	return []moonapi.Problem{
+		softProblem(1, "Ben", "Hold Set A", "7A", "6C+", 50, false),
+		softProblem(2, "Ben", "Hold Set A", "7A", "6C", 10, true),
+		softProblem(3, "Sam", "Hold Set B", "6B+", "6C", 30, true),
+		softProblem(4, "Sam", "Hold Set B", "6B+", "6B+", 100, true),
+		softProblem(5, "Sam", "", "7B", map[string]interface{}{"Grade": "7B"}, 20, true),
+		softProblem(6, "Alex", "Hold Set A", "7A", "7B", 2, false),
+		softProblem(7, "Alex", "Hold Set A", "7A", nil, 40, false),
+	}
+}
+
+func TestUserGrade(t *testing.T) {
+	cases := []struct {
+		value    interface{}
+		expected query.Grade
+		ok       bool
+	}{
+		{"6B+", query.SixBPlus, true},
+		{" 7a ", query.SevenA, true},
+		{map[string]interface{}{"Description": "8A"}, query.EightA, true},
+		{nil, 0, false},
+		{"", 0, false},
+		{12.0, 0, false},
+	}
+
+	for _, c := range cases {
+		grade, ok := UserGrade(moonapi.Problem{UserGrade: c.value})
+		if ok != c.ok || grade != c.expected {
+			t.Errorf("Expected %v to decode to %s %t, got %s %t", c.value, c.expected, c.ok, grade, ok)
+		}
+	}
+}
+
+func TestSoftness(t *testing.T) {
+	report, err := Softness(softProblems(), DefaultSoftnessOptions)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if report.Skipped != 2 || len(report.Problems) != 5 {
+		t.Errorf("Expected 5 compared and 2 skipped, got %d and %d", len(report.Problems), report.Skipped)
+	}
+
+	verdicts := map[int]Verdict{}
+	for _, pg := range report.Problems {
+		verdicts[pg.ID] = pg.Verdict
+	}
+	expected := map[int]Verdict{1: Soft, 2: Soft, 3: Sandbagged, 4: Fair, 5: Fair}
+	for id, verdict := range expected {
+		if verdicts[id] != verdict {
+			t.Errorf("Expected problem %d to be %s, got %s", id, verdict, verdicts[id])
+		}
+	}
+
+	if report.Problems[1].Delta != -2 {
+		t.Errorf("Expected problem 2 to be 2 grades soft, got %d", report.Problems[1].Delta)
+	}
+}
+
+func TestSoftnessThreshold(t *testing.T) {
+	report, _ := Softness(softProblems(), SoftnessOptions{Threshold: 2, MinRepeats: 0})
+
+	for _, pg := range report.Problems {
+		if pg.ID == 1 && pg.Verdict != Fair {
+			t.Errorf("Expected a grade out to be fair with a threshold of 2, got %s", pg.Verdict)
+		}
+		if pg.ID == 6 && pg.Verdict != Sandbagged {
+			t.Errorf("Expected problem 6 to be counted and sandbagged, got %s", pg.Verdict)
+		}
+	}
+}
+
+func TestSoftnessGroups(t *testing.T) {
+	report, _ := Softness(softProblems(), DefaultSoftnessOptions)
+
+	sam := report.BySetter[0]
+	if sam.Name != "Sam" || sam.Problems != 3 || sam.Sandbagged != 1 || sam.Fair != 2 || sam.AverageDelta != 1.0/3.0 {
+		t.Errorf("Unexpected summary for Sam, got %+v", sam)
+	}
+
+	ben := report.BySetter[1]
+	if ben.Name != "Ben" || ben.Soft != 2 || ben.AverageDelta != -1.5 {
+		t.Errorf("Unexpected summary for Ben, got %+v", ben)
+	}
+
+	var names []string
+	for _, g := range report.ByHoldSet {
+		names = append(names, g.Name)
+	}
+	if strings.Join(names, ",") != "hold set a,hold set b,unknown" {
+		t.Errorf("Unexpected hold set groups, got %v", names)
+	}
+}
+
+func TestSoftnessHonest(t *testing.T) {
+	report, _ := Softness(softProblems(), DefaultSoftnessOptions)
+	honest := report.Honest()
+
+	if len(honest) != 2 || honest[0].ID != 4 || honest[1].ID != 5 {
+		t.Errorf("Expected benchmarks 4 and 5, got %+v", honest)
+	}
+}
+
+func TestSoftnessInvalidOptions(t *testing.T) {
+	if _, err := Softness(softProblems(), SoftnessOptions{Threshold: 0}); err == nil {
+		t.Errorf("Expected an error with a threshold of 0")
+	}
+}
+
+func TestSoftnessCSV(t *testing.T) {
+	report, _ := Softness(softProblems(), DefaultSoftnessOptions)
+
+	var buffer bytes.Buffer
+	if err := report.WriteCSV(&buffer); err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	lines := strings.Split(buffer.String(), "\n")
+	if lines[1] != "1,,Ben,false,50,hold set a,7A,6C+,-1,soft" {
+		t.Errorf("Unexpected first row, got %s", lines[1])
+	}
+}
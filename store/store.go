@@ -0,0 +1,168 @@
+// Package store keeps problems downloaded from the website in a local
+// database file so they don't have to be fetched again.
+package store
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/cstdev/moonapi"
+	bolt "go.etcd.io/bbolt"
+)
+
+var problemsBucket = []byte("problems")
+var deletedBucket = []byte("deleted")
+var syncedBucket = []byte("synced")
+
+// ErrNotFound is returned when a problem is not in the store.
+var ErrNotFound = errors.New("problem not found in store")
+
+// Store holds Problems in a local database file keyed by their ID.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens the store at path, creating the file if it does not exist.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{problemsBucket, deletedBucket, syncedBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the database file.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Put adds or replaces a problem. Problems with a DateDeleted are recorded
+// as deleted and no longer returned by All.
+func (s *Store) Put(problem moonapi.Problem) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return put(tx, problem)
+	})
+}
+
+func put(tx *bolt.Tx, problem moonapi.Problem) error {
+	value, err := json.Marshal(problem)
+	if err != nil {
+		return err
+	}
+
+	key := idKey(problem.ID)
+	if err := tx.Bucket(problemsBucket).Put(key, value); err != nil {
+		return err
+	}
+
+	deleted, err := problem.Deleted()
+	if err != nil {
+		return err
+	}
+	if deleted.IsZero() {
+		return tx.Bucket(deletedBucket).Delete(key)
+	}
+
+	at, err := deleted.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(deletedBucket).Put(key, at)
+}
+
+// Get returns the problem with the given ID, including deleted problems.
+// ErrNotFound is returned if it has not been stored.
+func (s *Store) Get(id int) (*moonapi.Problem, error) {
+	var problem *moonapi.Problem
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		problem, err = get(tx, id)
+		return err
+	})
+	return problem, err
+}
+
+func get(tx *bolt.Tx, id int) (*moonapi.Problem, error) {
+	value := tx.Bucket(problemsBucket).Get(idKey(id))
+	if value == nil {
+		return nil, ErrNotFound
+	}
+
+	var problem moonapi.Problem
+	if err := json.Unmarshal(value, &problem); err != nil {
+		return nil, err
+	}
+	return &problem, nil
+}
+
+// Remove removes a problem from the store completely.
+func (s *Store) Remove(id int) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		key := idKey(id)
+		if err := tx.Bucket(problemsBucket).Delete(key); err != nil {
+			return err
+		}
+		return tx.Bucket(deletedBucket).Delete(key)
+	})
+}
+
+// All returns every stored problem that has not been deleted, in ID order.
+func (s *Store) All() ([]moonapi.Problem, error) {
+	var problems []moonapi.Problem
+	err := s.db.View(func(tx *bolt.Tx) error {
+		deleted := tx.Bucket(deletedBucket)
+		return tx.Bucket(problemsBucket).ForEach(func(key []byte, value []byte) error {
+			if deleted.Get(key) != nil {
+				return nil
+			}
+
+			var problem moonapi.Problem
+			if err := json.Unmarshal(value, &problem); err != nil {
+				return err
+			}
+			problems = append(problems, problem)
+			return nil
+		})
+	})
+	return problems, err
+}
+
+// Deleted returns the IDs of every problem recorded as deleted and when
+// it was deleted.
+func (s *Store) Deleted() (map[int]time.Time, error) {
+	deleted := map[int]time.Time{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(deletedBucket).ForEach(func(key []byte, value []byte) error {
+			var at time.Time
+			if err := at.UnmarshalBinary(value); err != nil {
+				return err
+			}
+			deleted[int(binary.BigEndian.Uint64(key))] = at
+			return nil
+		})
+	})
+	return deleted, err
+}
+
+// idKey encodes an ID big endian so problems are iterated in ID order.
+func idKey(id int) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(id))
+	return key
+}
@@ -0,0 +1,117 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cstdev/moonapi"
+)
+
+func openTestStore(t *testing.T) (*Store, func()) {
+	dir, err := ioutil.TempDir("", "moonapi-store")
+	if err != nil {
+		t.Fatalf("Unable to create temp dir: %v", err)
+	}
+
+	s, err := Open(filepath.Join(dir, "problems.db"))
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("Unable to open store: %v", err)
+	}
+
+	return s, func() {
+		s.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func testProblem(id int, name string) moonapi.Problem {
+	return moonapi.Problem{
+		ID:           id,
+		Name:         name,
+		Grade:        "7A",
+		DateInserted: "/Date(1524237072990)/",
+	}
+}
+
+func TestPutAndGetProblem(t *testing.T) {
+	s, cleanup := openTestStore(t)
+	defer cleanup()
+
+	err := s.Put(testProblem(318731, "SOFT WOOD RH"))
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	problem, err := s.Get(318731)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if problem.Name != "SOFT WOOD RH" {
+		t.Errorf("Problem was incorrect, got %s", problem.Name)
+	}
+}
+
+func TestGetMissingProblemReturnsNotFound(t *testing.T) {
+	s, cleanup := openTestStore(t)
+	defer cleanup()
+
+	_, err := s.Get(1)
+	if err != ErrNotFound {
+		t.Errorf("Incorrect error provided. Got: %v Expected: %v", err, ErrNotFound)
+	}
+}
+
+func TestAllSkipsDeletedProblems(t *testing.T) {
+	s, cleanup := openTestStore(t)
+	defer cleanup()
+
+	deleted := testProblem(2, "Deleted")
+	deleted.DateDeleted = "/Date(1524237080000)/"
+
+	s.Put(testProblem(3, "Third"))
+	s.Put(deleted)
+	s.Put(testProblem(1, "First"))
+
+	problems, err := s.All()
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if len(problems) != 2 || problems[0].ID != 1 || problems[1].ID != 3 {
+		t.Errorf("Expected problems 1 and 3, got %+v", problems)
+	}
+
+	deletions, err := s.Deleted()
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if deletions[2].Unix() != 1524237080 {
+		t.Errorf("Expected problem 2 to be deleted, got %v", deletions)
+	}
+}
+
+func TestRemoveProblem(t *testing.T) {
+	s, cleanup := openTestStore(t)
+	defer cleanup()
+
+	s.Put(testProblem(1, "First"))
+	err := s.Remove(1)
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	_, err = s.Get(1)
+	if err != ErrNotFound {
+		t.Errorf("Incorrect error provided. Got: %v Expected: %v", err, ErrNotFound)
+	}
+}
@@ -0,0 +1,192 @@
+package store
+
+import (
+	"time"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/query"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Getter fetches a page of problems, it is satisfied by moonapi.MoonBoard.
+type Getter interface {
+	GetProblems(query query.Query) (moonapi.MbResponse, error)
+}
+
+// SyncResult counts the changes made to the store by Sync. Full is true if
+// every page was fetched rather than stopping at stored problems.
+type SyncResult struct {
+	Added     int
+	Updated   int
+	Deleted   int
+	Unchanged int
+	Pages     int
+	Full      bool
+}
+
+// Sync fetches the problems matching the query, newest first, and stores
+// any that are new or have changed. Once every page of the query has been
+// stored, fetching stops at the end of the first page that holds a problem
+// which is already stored and unchanged, as everything older will have been
+// stored by an earlier Sync. Until then, e.g. if the first Sync was
+// interrupted, every page is fetched as FullSync does.
+// The sort order, page and page size of the query are ignored.
+func (s *Store) Sync(client Getter, q query.Query) (SyncResult, error) {
+	return s.sync(client, q, false)
+}
+
+// FullSync fetches every page of problems matching the query and stores
+// any that are new or have changed, picking up changes to older problems
+// that Sync stops before reaching.
+func (s *Store) FullSync(client Getter, q query.Query) (SyncResult, error) {
+	return s.sync(client, q, true)
+}
+
+func (s *Store) sync(client Getter, q query.Query, full bool) (SyncResult, error) {
+	var result SyncResult
+
+	definition, err := query.DefinitionOf(q)
+	if err != nil {
+		return result, err
+	}
+	definition.Sort = []query.SortKey{{Order: query.Newest, Asc: false}}
+	definition.PageSize = query.MaxPageSize
+
+	key := []byte(q.Filter())
+	if !full {
+		err = s.db.View(func(tx *bolt.Tx) error {
+			full = tx.Bucket(syncedBucket).Get(key) == nil
+			return nil
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+	result.Full = full
+
+	for page := 1; ; page++ {
+		definition.Page = page
+		pageQuery, errs := query.FromDefinition(definition).Build()
+		if len(errs) > 0 {
+			return result, errs[0]
+		}
+
+		res, err := client.GetProblems(pageQuery)
+		if err != nil {
+			return result, err
+		}
+		result.Pages++
+
+		caughtUp := false
+		lastPage := len(res.Data) < query.MaxPageSize || page*query.MaxPageSize >= res.Total
+		err = s.db.Update(func(tx *bolt.Tx) error {
+			for _, problem := range res.Data {
+				change, err := compare(tx, problem)
+				if err != nil {
+					return err
+				}
+
+				switch change {
+				case unchanged:
+					result.Unchanged++
+					caughtUp = true
+					continue
+				case added:
+					result.Added++
+				case updated:
+					result.Updated++
+				case deleted:
+					result.Deleted++
+				}
+
+				if err := put(tx, problem); err != nil {
+					return err
+				}
+			}
+
+			if full && lastPage {
+				return markSynced(tx, key)
+			}
+			return nil
+		})
+		if err != nil {
+			return result, err
+		}
+
+		if (caughtUp && !full) || lastPage {
+			return result, nil
+		}
+	}
+}
+
+// markSynced records when every page of the query with the given filter
+// was last stored.
+func markSynced(tx *bolt.Tx, key []byte) error {
+	at, err := time.Now().MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(syncedBucket).Put(key, at)
+}
+
+type change int
+
+const (
+	unchanged change = iota
+	added
+	updated
+	deleted
+)
+
+// compare works out how a fetched problem differs from the stored one
+// using its DateInserted, DateUpdated and DateDeleted.
+func compare(tx *bolt.Tx, problem moonapi.Problem) (change, error) {
+	stored, err := get(tx, problem.ID)
+	if err == ErrNotFound {
+		return added, nil
+	}
+	if err != nil {
+		return unchanged, err
+	}
+
+	fetchedDeleted, err := problem.Deleted()
+	if err != nil {
+		return unchanged, err
+	}
+	storedDeleted, err := stored.Deleted()
+	if err != nil {
+		return unchanged, err
+	}
+	if !fetchedDeleted.Equal(storedDeleted) {
+		if fetchedDeleted.IsZero() {
+			return updated, nil
+		}
+		return deleted, nil
+	}
+
+	fetchedInserted, err := problem.Inserted()
+	if err != nil {
+		return unchanged, err
+	}
+	storedInserted, err := stored.Inserted()
+	if err != nil {
+		return unchanged, err
+	}
+	if !fetchedInserted.Equal(storedInserted) {
+		return updated, nil
+	}
+
+	fetchedUpdated, err := problem.Updated()
+	if err != nil {
+		return unchanged, err
+	}
+	storedUpdated, err := stored.Updated()
+	if err != nil {
+		return unchanged, err
+	}
+	if !fetchedUpdated.Equal(storedUpdated) {
+		return updated, nil
+	}
+
+	return unchanged, nil
+}
@@ -0,0 +1,181 @@
+package store
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cstdev/moonapi"
+	"github.com/cstdev/moonapi/query"
+)
+
+// fakeGetter returns problems newest first, as the website would with the
+// Newest sort order.
+type fakeGetter struct {
+	problems []moonapi.Problem
+	queries  []query.Query
+	failPage int
+}
+
+func (g *fakeGetter) GetProblems(q query.Query) (moonapi.MbResponse, error) {
+	g.queries = append(g.queries, q)
+	if q.Page() == g.failPage {
+		return moonapi.MbResponse{}, errors.New("connection reset")
+	}
+
+	start := (q.Page() - 1) * q.PageSize()
+	end := start + q.PageSize()
+	if start > len(g.problems) {
+		start = len(g.problems)
+	}
+	if end > len(g.problems) {
+		end = len(g.problems)
+	}
+	return moonapi.MbResponse{Data: g.problems[start:end], Total: len(g.problems)}, nil
+}
+
+func buildQuery(builder query.QueryBuilder) query.Query {
+	q, _ := builder.Build()
+	return q
+}
+
+func newestFirst(count int) []moonapi.Problem {
+	var problems []moonapi.Problem
+	for id := count; id > 0; id-- {
+		problems = append(problems, testProblem(id, "Problem"))
+	}
+	return problems
+}
+
+func TestSyncStoresEveryPage(t *testing.T) {
+	s, cleanup := openTestStore(t)
+	defer cleanup()
+
+	client := &fakeGetter{problems: newestFirst(250)}
+	result, err := s.Sync(client, buildQuery(query.New().Filter(query.Benchmarks)))
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if result.Added != 250 || result.Pages != 3 {
+		t.Errorf("Sync result was incorrect, got %+v", result)
+	}
+
+	if client.queries[0].Sort() != "New-desc" || client.queries[0].PageSize() != 100 {
+		t.Errorf("Expected newest first in pages of 100, got %s %d", client.queries[0].Sort(), client.queries[0].PageSize())
+	}
+
+	problems, _ := s.All()
+	if len(problems) != 250 {
+		t.Errorf("Expected 250 stored problems, got %d", len(problems))
+	}
+}
+
+func TestSyncStopsAtStoredProblems(t *testing.T) {
+	s, cleanup := openTestStore(t)
+	defer cleanup()
+
+	client := &fakeGetter{problems: newestFirst(250)}
+	s.Sync(client, buildQuery(query.New()))
+
+	client.problems = newestFirst(260)
+	client.queries = nil
+	result, err := s.Sync(client, buildQuery(query.New()))
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if result.Added != 10 || result.Pages != 1 || result.Unchanged != 90 {
+		t.Errorf("Sync result was incorrect, got %+v", result)
+	}
+}
+
+func TestSyncRecordsUpdatesAndDeletions(t *testing.T) {
+	s, cleanup := openTestStore(t)
+	defer cleanup()
+
+	client := &fakeGetter{problems: newestFirst(5)}
+	s.Sync(client, buildQuery(query.New()))
+
+	client.problems[0].DateUpdated = "/Date(1524237090000)/"
+	client.problems[1].DateDeleted = "/Date(1524237095000)/"
+	result, err := s.Sync(client, buildQuery(query.New()))
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+
+	if result.Updated != 1 || result.Deleted != 1 || result.Unchanged != 3 {
+		t.Errorf("Sync result was incorrect, got %+v", result)
+	}
+
+	deletions, _ := s.Deleted()
+	if _, ok := deletions[4]; !ok || len(deletions) != 1 {
+		t.Errorf("Expected problem 4 to be deleted, got %v", deletions)
+	}
+}
+
+func TestSyncFetchesEveryPageUntilAFullSyncCompletes(t *testing.T) {
+	s, cleanup := openTestStore(t)
+	defer cleanup()
+
+	client := &fakeGetter{problems: newestFirst(250), failPage: 3}
+	_, err := s.Sync(client, buildQuery(query.New()))
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+		t.FailNow()
+	}
+
+	client.failPage = 0
+	result, err := s.Sync(client, buildQuery(query.New()))
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+	if !result.Full || result.Added != 50 || result.Pages != 3 {
+		t.Errorf("Expected the interrupted sync to be completed, got %+v", result)
+	}
+
+	result, _ = s.Sync(client, buildQuery(query.New()))
+	if result.Full || result.Pages != 1 {
+		t.Errorf("Expected a completed sync to stop at stored problems, got %+v", result)
+	}
+}
+
+func TestFullSyncPicksUpChangesToOlderProblems(t *testing.T) {
+	s, cleanup := openTestStore(t)
+	defer cleanup()
+
+	client := &fakeGetter{problems: newestFirst(250)}
+	s.Sync(client, buildQuery(query.New()))
+
+	client.problems[240].DateUpdated = "/Date(1524237090000)/"
+	result, _ := s.Sync(client, buildQuery(query.New()))
+	if result.Updated != 0 {
+		t.Errorf("Expected Sync to stop before the older change, got %+v", result)
+	}
+
+	result, err := s.FullSync(client, buildQuery(query.New()))
+	if err != nil {
+		t.Errorf("Unexpected error. %s", err.Error())
+		t.FailNow()
+	}
+	if !result.Full || result.Updated != 1 || result.Pages != 3 {
+		t.Errorf("Expected FullSync to update the older problem, got %+v", result)
+	}
+}
+
+func TestSyncDetectsChangedDateInserted(t *testing.T) {
+	s, cleanup := openTestStore(t)
+	defer cleanup()
+
+	client := &fakeGetter{problems: newestFirst(5)}
+	s.Sync(client, buildQuery(query.New()))
+
+	client.problems[2].DateInserted = "/Date(1524237099000)/"
+	result, _ := s.Sync(client, buildQuery(query.New()))
+	if result.Updated != 1 || result.Unchanged != 4 {
+		t.Errorf("Sync result was incorrect, got %+v", result)
+	}
+}
@@ -0,0 +1,141 @@
+package moonapi
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/headzoo/surf/browser"
+)
+
+// Rejection is the kind of problem the website found with a submitted form.
+type Rejection string
+
+const (
+	NameTaken    Rejection = "name taken"
+	InvalidHolds Rejection = "invalid holds"
+	InvalidGrade Rejection = "invalid grade"
+	InvalidValue Rejection = "invalid value"
+)
+
+// SubmitError is a validation message the website gave for a submitted
+// form. Field is the form field it was given for, empty if it was about the
+// whole form.
+type SubmitError struct {
+	Kind    Rejection
+	Field   string
+	Message string
+}
+
+func (e *SubmitError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// RejectedError is returned when the website rejects a submitted form,
+// holding every validation message it gave.
+type RejectedError struct {
+	Errors []*SubmitError
+}
+
+func (e *RejectedError) Error() string {
+	var messages []string
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return "rejected by the website: " + strings.Join(messages, "; ")
+}
+
+// Has returns true if any of the validation messages are of the given kind.
+func (e *RejectedError) Has(kind Rejection) bool {
+	for _, err := range e.Errors {
+		if err.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// rejection reads the validation messages from a page the website returned
+// a form on, or returns nil if there are none.
+func rejection(bow *browser.Browser) error {
+	var errs []*SubmitError
+
+	bow.Find(".field-validation-error").Each(func(_ int, s *goquery.Selection) {
+		field, _ := s.Attr("data-valmsg-for")
+		errs = append(errs, submitError(field, s.Text()))
+	})
+	bow.Find(".validation-summary-errors li").Each(func(_ int, s *goquery.Selection) {
+		errs = append(errs, submitError("", s.Text()))
+	})
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return &RejectedError{Errors: errs}
+}
+
+// submitError works out the kind of a validation message from the field it
+// was given for and what it says.
+func submitError(field string, message string) *SubmitError {
+	message = strings.TrimSpace(message)
+	lower := strings.ToLower(field + " " + message)
+
+	kind := InvalidValue
+	switch {
+	case strings.Contains(lower, "name") && (strings.Contains(lower, "already") || strings.Contains(lower, "exists") || strings.Contains(lower, "taken")):
+		kind = NameTaken
+	case strings.Contains(lower, "hold") || strings.Contains(lower, "move"):
+		kind = InvalidHolds
+	case strings.Contains(lower, "grade"):
+		kind = InvalidGrade
+	}
+	return &SubmitError{Kind: kind, Field: field, Message: message}
+}
+
+// formField is a value to enter into a field of a form on the website.
+type formField struct {
+	name  string
+	value string
+}
+
+// submitForm opens the page at path, enters the fields into the form
+// matching selector and submits it, returning the browser on the page the
+// website responded with. name describes the form in errors.
+// errors if the session has expired, the form or one of its fields is
+// missing, or the server returns an error status
+func (m MoonBoard) submitForm(path string, selector string, name string, fields []formField) (*browser.Browser, error) {
+	bow, err := m.browser()
+	if err != nil {
+		return nil, err
+	}
+
+	err = bow.Open(baseUrl + path)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(bow); err != nil {
+		return nil, err
+	}
+
+	fm, err := bow.Form(selector)
+	if err != nil {
+		return nil, errors.New("unable to find the " + name + " form")
+	}
+
+	for _, field := range fields {
+		if err := fm.Input(field.name, field.value); err != nil {
+			return nil, errors.New(name + " form is missing the " + field.name + " field")
+		}
+	}
+
+	if err := fm.Submit(); err != nil {
+		return nil, err
+	}
+	if err := checkResponse(bow); err != nil {
+		return nil, err
+	}
+	return bow, nil
+}
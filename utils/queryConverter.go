@@ -1,7 +1,6 @@
 package utils
 
 import (
-	"errors"
 	"strconv"
 	"strings"
 
@@ -11,10 +10,15 @@ import (
 
 // RequestQuery represents all the fields available in a query but as
 // strings.
+// Order can hold several sort orders split by comma, each optionally
+// followed by its own direction, e.g. "Grade-asc,Rating". Orders without a
+// direction use Asc.
+// Configuration and HoldSet can hold several values split by comma.
 type RequestQuery struct {
 	Term          string
 	Order         string
 	Asc           string
+	Setup         string
 	Configuration string
 	HoldSet       string
 	Filter        string
@@ -24,32 +28,79 @@ type RequestQuery struct {
 	PageSize      string
 }
 
+// builderFields maps the builder method named by a query.BuildError to the
+// RequestQuery field its value came from, where the names differ.
+var builderFields = map[string]string{
+	"Sort": "Order",
+}
+
 // Query takes a RequestQuery and converts it properties to the correct types
 // to make a Query object which can then be used to perform a search.
+// A *ValidationError listing every invalid field is returned if any of the
+// values cannot be converted or the resulting query is not valid.
 func (q *RequestQuery) Query() (query.Query, error) {
+	built, fieldErrs := q.build()
+	if len(fieldErrs) > 0 {
+		return nil, &ValidationError{Errors: fieldErrs}
+	}
+	return built, nil
+}
+
+// build converts the RequestQuery into a Query, collecting a FieldError for
+// every value that cannot be converted and every error returned by Build.
+func (q *RequestQuery) build() (query.Query, []FieldError) {
+	builder, fieldErrs := q.builder()
+
+	log.WithFields(log.Fields{
+		"RequestQuery": q,
+	}).Debug("Building query.")
+
+	built, errs := builder.Build()
+	for _, err := range errs {
+		fieldErr := FieldError{Reason: err.Error()}
+		if buildErr, ok := err.(*query.BuildError); ok {
+			fieldErr.Field = buildErr.Field
+			if field, ok := builderFields[buildErr.Field]; ok {
+				fieldErr.Field = field
+			}
+			fieldErr.Value = buildErr.Value
+		}
+		fieldErrs = append(fieldErrs, fieldErr)
+	}
+	return built, fieldErrs
+}
+
+// builder converts each field of the RequestQuery and sets it on a new
+// QueryBuilder, collecting an error for every field that cannot be converted.
+func (q *RequestQuery) builder() (query.QueryBuilder, []FieldError) {
+	var fieldErrs []FieldError
+	invalid := func(field string, value string, reason string) {
+		fieldErrs = append(fieldErrs, FieldError{Field: field, Value: value, Reason: reason})
+	}
+
 	var asc bool
 	var page int
 	var pageSize int
-	var err error
+	var err, pageErr, pageSizeErr error
 
 	if q.Page != "" {
-		page, err = strconv.Atoi(q.Page)
-		if err != nil {
-			return nil, errors.New("Invalid page number")
+		page, pageErr = strconv.Atoi(q.Page)
+		if pageErr != nil {
+			invalid("Page", q.Page, "Invalid page number")
 		}
 	}
 
 	if q.PageSize != "" {
-		pageSize, err = strconv.Atoi(q.PageSize)
-		if err != nil {
-			return nil, errors.New("Invalid page size.")
+		pageSize, pageSizeErr = strconv.Atoi(q.PageSize)
+		if pageSizeErr != nil {
+			invalid("PageSize", q.PageSize, "Invalid page size.")
 		}
 	}
 
 	if q.Asc != "" {
 		asc, err = strconv.ParseBool(q.Asc)
 		if err != nil {
-			return nil, errors.New("Invalid ascending value, should be 'true' or 'false'")
+			invalid("Asc", q.Asc, "Invalid ascending value, should be 'true' or 'false'")
 		}
 	}
 
@@ -60,19 +111,50 @@ func (q *RequestQuery) Query() (query.Query, error) {
 	}
 
 	if q.Order != "" {
-		orderType, err := query.ToOrder(q.Order)
+		for _, order := range strings.Split(q.Order, ",") {
+			keyAsc := asc
+			order = strings.TrimSpace(order)
+			if i := strings.LastIndex(order, "-"); i >= 0 {
+				switch strings.ToLower(order[i+1:]) {
+				case "asc":
+					keyAsc = true
+				case "desc":
+					keyAsc = false
+				default:
+					invalid("Order", order, "Invalid sort direction, should be 'asc' or 'desc'")
+					continue
+				}
+				order = order[:i]
+			}
+
+			orderType, err := query.ToOrder(order)
+			if err != nil {
+				invalid("Order", order, err.Error())
+				continue
+			}
+			builder.Sort(*orderType, keyAsc)
+		}
+	}
+
+	if q.Setup != "" {
+		setupType, err := query.ToSetup(q.Setup)
 		if err != nil {
-			return nil, err
+			invalid("Setup", q.Setup, err.Error())
+		} else {
+			builder.Setup(*setupType)
 		}
-		builder.Sort(*orderType, asc)
 	}
 
 	if q.Configuration != "" {
-		configType, err := query.ToConfiguration(q.Configuration)
-		if err != nil {
-			return nil, err
+		for _, config := range strings.Split(q.Configuration, ",") {
+			config = strings.TrimSpace(config)
+			configType, err := query.ToConfiguration(config)
+			if err != nil {
+				invalid("Configuration", config, err.Error())
+				continue
+			}
+			builder.Configuration(*configType)
 		}
-		builder.Configuration(*configType)
 	}
 
 	if q.HoldSet != "" {
@@ -84,7 +166,8 @@ func (q *RequestQuery) Query() (query.Query, error) {
 			}).Debug("Split hold sets")
 			holdType, err := query.ToHoldSet(set)
 			if err != nil {
-				return nil, err
+				invalid("HoldSet", set, err.Error())
+				continue
 			}
 
 			builder.HoldSet(*holdType)
@@ -94,45 +177,37 @@ func (q *RequestQuery) Query() (query.Query, error) {
 	if q.Filter != "" {
 		filterType, err := query.ToFilter(q.Filter)
 		if err != nil {
-			return nil, err
+			invalid("Filter", q.Filter, err.Error())
+		} else {
+			builder.Filter(*filterType)
 		}
-		builder.Filter(*filterType)
 	}
 
 	if q.MinGrade != "" {
-		minGradeType, err := query.ToGrade(q.MinGrade)
-		if err != nil {
-			return nil, err
+		var grade query.Grade
+		if err := grade.UnmarshalText([]byte(q.MinGrade)); err != nil {
+			invalid("MinGrade", q.MinGrade, err.Error())
+		} else {
+			builder.MinGrade(grade)
 		}
-
-		builder.MinGrade(*minGradeType)
 	}
 
 	if q.MaxGrade != "" {
-		maxGradeType, err := query.ToGrade(q.MaxGrade)
-		if err != nil {
-			return nil, err
+		var grade query.Grade
+		if err := grade.UnmarshalText([]byte(q.MaxGrade)); err != nil {
+			invalid("MaxGrade", q.MaxGrade, err.Error())
+		} else {
+			builder.MaxGrade(grade)
 		}
-
-		builder.MaxGrade(*maxGradeType)
 	}
 
-	if page > 0 {
+	if q.Page != "" && pageErr == nil {
 		builder.Page(page)
 	}
 
-	if pageSize > 0 {
-
+	if q.PageSize != "" && pageSizeErr == nil {
 		builder.PageSize(pageSize)
 	}
 
-	log.WithFields(log.Fields{
-		"RequestQuery": q,
-	}).Debug("Building query.")
-
-	query, errs := builder.Build()
-	if err != nil {
-		return nil, errs[0]
-	}
-	return query, nil
+	return builder, fieldErrs
 }
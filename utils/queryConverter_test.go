@@ -49,7 +49,20 @@ func TestOrderAscIsAddedToTheQuery(t *testing.T) {
 	query, err := req.Query()
 	checkError(t, err)
 
-	expected := "GradeAsc-asc"
+	expected := "Grade-asc"
+	compare(query.Sort(), expected, t)
+}
+
+func TestMultipleOrdersAreAddedToTheQuery(t *testing.T) {
+	req := &RequestQuery{
+		Order: "Grade-desc, Name",
+		Asc:   "true",
+	}
+
+	query, err := req.Query()
+	checkError(t, err)
+
+	expected := "Grade-desc~Name-asc"
 	compare(query.Sort(), expected, t)
 }
 
@@ -170,3 +183,112 @@ func TestSearchTermIsAddedToQuery(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestBuildErrorsAreReturned(t *testing.T) {
+	req := &RequestQuery{
+		MinGrade: "8A",
+		MaxGrade: "7A",
+		Order:    "Grade,Grade",
+	}
+
+	_, err := req.Query()
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+		t.FailNow()
+	}
+
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Errorf("Expected a *ValidationError, got %T", err)
+		t.FailNow()
+	}
+
+	expected := []FieldError{
+		{Field: "Order", Value: "Grade", Reason: "can only sort by Grade once, defaulting to the last provided"},
+		{Field: "MinGrade", Value: "8A", Reason: "min grade cannot be higher than max grade"},
+	}
+	if len(validationErr.Errors) != len(expected) {
+		t.Errorf("Expected %d errors, got %d: %v", len(expected), len(validationErr.Errors), validationErr)
+		t.FailNow()
+	}
+
+	for i, fieldErr := range expected {
+		if validationErr.Errors[i] != fieldErr {
+			t.Errorf("Expected:\n %+v \n Recieved: \n %+v", fieldErr, validationErr.Errors[i])
+		}
+	}
+}
+
+func TestParseAndBuildErrorsAreReturnedTogether(t *testing.T) {
+	req := &RequestQuery{
+		PageSize: "150",
+		Page:     "one",
+		HoldSet:  "Z",
+	}
+
+	_, err := req.Query()
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+		t.FailNow()
+	}
+
+	validationErr := err.(*ValidationError)
+	expected := []string{"Page", "HoldSet", "PageSize"}
+	if len(validationErr.Errors) != len(expected) {
+		t.Errorf("Expected %d errors, got %d: %v", len(expected), len(validationErr.Errors), validationErr)
+		t.FailNow()
+	}
+
+	for i, field := range expected {
+		if validationErr.Errors[i].Field != field {
+			t.Errorf("Expected error %d to be for %s, got %s", i, field, validationErr.Errors[i].Field)
+		}
+	}
+}
+
+func TestZeroAndNegativePagesAreReported(t *testing.T) {
+	req := &RequestQuery{
+		Page:     "0",
+		PageSize: "-1",
+	}
+
+	_, err := req.Query()
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+		t.FailNow()
+	}
+
+	validationErr := err.(*ValidationError)
+	expected := []FieldError{
+		{Field: "Page", Value: "0", Reason: "page number cannot be below 1"},
+		{Field: "PageSize", Value: "-1", Reason: "Page size must be between 1 and 100"},
+	}
+	if len(validationErr.Errors) != len(expected) {
+		t.Errorf("Expected %d errors, got %d: %v", len(expected), len(validationErr.Errors), validationErr)
+		t.FailNow()
+	}
+
+	for i, fieldErr := range expected {
+		if validationErr.Errors[i] != fieldErr {
+			t.Errorf("Expected:\n %+v \n Recieved: \n %+v", fieldErr, validationErr.Errors[i])
+		}
+	}
+}
+
+func TestUnknownGradesAreReported(t *testing.T) {
+	req := &RequestQuery{
+		MinGrade: "6Z",
+		MaxGrade: "8B+",
+	}
+
+	_, err := req.Query()
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+		t.FailNow()
+	}
+
+	validationErr := err.(*ValidationError)
+	if len(validationErr.Errors) != 1 || validationErr.Errors[0].Field != "MinGrade" || validationErr.Errors[0].Value != "6Z" {
+		t.Errorf("Expected a single MinGrade error for 6Z, got %v", validationErr)
+	}
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// FieldError describes a single value that could not be used in a query.
+// Field is the name the value was provided under, either the RequestQuery
+// field or the request parameter.
+type FieldError struct {
+	Field  string `json:"field"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+func (e FieldError) Error() string {
+	return e.Field + " '" + e.Value + "': " + e.Reason
+}
+
+// ValidationError holds every invalid value found, so they can all be
+// reported together, e.g. in the body of a 400 response.
+type ValidationError struct {
+	Errors []FieldError `json:"errors"`
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		messages[i] = fieldErr.Error()
+	}
+	return "invalid query: " + strings.Join(messages, "; ")
+}
+
+// paramNames maps each RequestQuery field to the request parameter it is
+// read from.
+var paramNames = map[string]string{
+	"Term":          "term",
+	"Order":         "order",
+	"Asc":           "asc",
+	"Setup":         "setup",
+	"Configuration": "config",
+	"HoldSet":       "holdset",
+	"Filter":        "filter",
+	"MinGrade":      "min",
+	"MaxGrade":      "max",
+	"Page":          "page",
+	"PageSize":      "pageSize",
+}
+
+// FromValues creates a RequestQuery from request parameters: term, order,
+// asc, setup, config, holdset, filter, min, max, page and pageSize.
+// order, config and holdset can be repeated or split by comma.
+// The values are checked as Query would and a *ValidationError naming every
+// invalid parameter is returned alongside the RequestQuery if any are wrong.
+func FromValues(values url.Values) (*RequestQuery, error) {
+	q := &RequestQuery{
+		Term:          values.Get(paramNames["Term"]),
+		Order:         strings.Join(values[paramNames["Order"]], ","),
+		Asc:           values.Get(paramNames["Asc"]),
+		Setup:         values.Get(paramNames["Setup"]),
+		Configuration: strings.Join(values[paramNames["Configuration"]], ","),
+		HoldSet:       strings.Join(values[paramNames["HoldSet"]], ","),
+		Filter:        values.Get(paramNames["Filter"]),
+		MinGrade:      values.Get(paramNames["MinGrade"]),
+		MaxGrade:      values.Get(paramNames["MaxGrade"]),
+		Page:          values.Get(paramNames["Page"]),
+		PageSize:      values.Get(paramNames["PageSize"]),
+	}
+
+	_, fieldErrs := q.build()
+	if len(fieldErrs) > 0 {
+		for i := range fieldErrs {
+			if param, ok := paramNames[fieldErrs[i].Field]; ok {
+				fieldErrs[i].Field = param
+			}
+		}
+		return q, &ValidationError{Errors: fieldErrs}
+	}
+	return q, nil
+}
+
+// FromRequest creates a RequestQuery from the parameters of an HTTP request,
+// both the URL query and any form body, as described by FromValues.
+func FromRequest(r *http.Request) (*RequestQuery, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	return FromValues(r.Form)
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestFromValuesMapsParameters(t *testing.T) {
+	values := url.Values{
+		"term":     {"a climb"},
+		"order":    {"Grade-asc", "New"},
+		"asc":      {"false"},
+		"config":   {"Forty"},
+		"holdset":  {"A,B", "OS"},
+		"min":      {"7A"},
+		"max":      {"7C"},
+		"page":     {"2"},
+		"pageSize": {"50"},
+	}
+
+	req, err := FromValues(values)
+	checkError(t, err)
+
+	query, err := req.Query()
+	checkError(t, err)
+
+	expected := "Configuration~eq~'40° MoonBoard'~and~Name~contains~'a climb'~and~Holdsets~eq~'hold set a,hold set b,original school holds'~and~MinGrade~eq~'7A'~and~MaxGrade~eq~'7C'"
+	compare(query.Filter(), expected, t)
+	compare(query.Sort(), "Grade-asc~New-desc", t)
+
+	if query.Page() != 2 || query.PageSize() != 50 {
+		t.Errorf("Expected page 2 of size 50, got page %d of size %d", query.Page(), query.PageSize())
+	}
+}
+
+func TestFromValuesReportsEveryInvalidParameter(t *testing.T) {
+	values := url.Values{
+		"order":   {"Grade-sideways"},
+		"holdset": {"A,Z"},
+		"page":    {"two"},
+	}
+
+	_, err := FromValues(values)
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+		t.FailNow()
+	}
+
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Errorf("Expected a *ValidationError, got %T", err)
+		t.FailNow()
+	}
+
+	expected := []string{"page", "order", "holdset"}
+	if len(validationErr.Errors) != len(expected) {
+		t.Errorf("Expected %d errors, got %d: %v", len(expected), len(validationErr.Errors), validationErr)
+		t.FailNow()
+	}
+
+	for i, field := range expected {
+		if validationErr.Errors[i].Field != field {
+			t.Errorf("Expected error %d to be for %s, got %s", i, field, validationErr.Errors[i].Field)
+		}
+	}
+
+	if validationErr.Errors[2].Value != "Z" {
+		t.Errorf("Expected invalid value Z, got %s", validationErr.Errors[2].Value)
+	}
+}
+
+func TestFromRequestReadsQueryString(t *testing.T) {
+	r := httptest.NewRequest("GET", "/problems?filter=Benchmarks&holdset=A&holdset=B", nil)
+
+	req, err := FromRequest(r)
+	checkError(t, err)
+
+	compare(req.Filter, "Benchmarks", t)
+	compare(req.HoldSet, "A,B", t)
+}
+
+func TestFromValuesNamesParametersInBuildErrors(t *testing.T) {
+	values := url.Values{
+		"config": {"Forty"},
+		"min":    {"6A"},
+	}
+
+	_, err := FromValues(values)
+	if err == nil {
+		t.Errorf("Expected error not recieved")
+		t.FailNow()
+	}
+
+	validationErr := err.(*ValidationError)
+	if len(validationErr.Errors) != 1 || validationErr.Errors[0].Field != "min" {
+		t.Errorf("Expected a single error for min, got %v", validationErr)
+	}
+}